@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/export"
+	"github.com/co0p/gopomodoro/pkg/history"
+	"github.com/co0p/gopomodoro/pkg/xdg"
+)
+
+// dateLayout is the form of the -from and -to dates.
+const dateLayout = "2006-01-02"
+
+// runExport implements `gopomodoro export`, writing the session history in
+// a format other tools can import.
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", string(export.CSV), "output format: csv, json, ics or org")
+	from := fs.String("from", "", "first day to export (YYYY-MM-DD)")
+	to := fs.String("to", "", "last day to export (YYYY-MM-DD)")
+	phases := fs.String("phase", "", "comma-separated phases to export: pomodoro, shortbreak, longbreak")
+	output := fs.String("o", "", "write to this file instead of stdout")
+	fs.Parse(args)
+
+	f, err := export.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+	filter := export.Filter{}
+	if filter.Phases, err = export.ParsePhases(*phases); err != nil {
+		return err
+	}
+	if *from != "" {
+		if filter.From, err = time.ParseInLocation(dateLayout, *from, time.Local); err != nil {
+			return fmt.Errorf("invalid -from date: %w", err)
+		}
+	}
+	if *to != "" {
+		day, err := time.ParseInLocation(dateLayout, *to, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid -to date: %w", err)
+		}
+		// The last day is included.
+		filter.To = day.AddDate(0, 0, 1)
+	}
+
+	dataDir, err := xdg.DataDir()
+	if err != nil {
+		return err
+	}
+	log := &history.Log{Path: filepath.Join(dataDir, history.FileName)}
+	records, err := log.Query(filter.From, filter.To)
+	if err != nil {
+		return err
+	}
+
+	records = filter.Apply(records)
+	if *output == "" {
+		return export.Write(os.Stdout, f, records)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := export.Write(file, f, records); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,8 @@
+package main
+
+import "github.com/co0p/gopomodoro/pkg/idle"
+
+// idleSource returns how idleness is detected on this platform.
+func idleSource() idle.Source {
+	return idle.Logind{}
+}
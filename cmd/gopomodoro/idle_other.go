@@ -0,0 +1,11 @@
+//go:build !linux
+
+package main
+
+import "github.com/co0p/gopomodoro/pkg/idle"
+
+// idleSource returns how idleness is detected on this platform; there is no
+// detection outside Linux yet.
+func idleSource() idle.Source {
+	return nil
+}
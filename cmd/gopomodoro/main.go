@@ -1,19 +1,147 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/calendar"
+	"github.com/co0p/gopomodoro/pkg/history"
+	"github.com/co0p/gopomodoro/pkg/idle"
+	"github.com/co0p/gopomodoro/pkg/inventory"
+	"github.com/co0p/gopomodoro/pkg/journal"
+	"github.com/co0p/gopomodoro/pkg/profile"
+	"github.com/co0p/gopomodoro/pkg/schedule"
 	"github.com/co0p/gopomodoro/pkg/sound"
+	"github.com/co0p/gopomodoro/pkg/stats"
 	"github.com/co0p/gopomodoro/pkg/ticker"
 	"github.com/co0p/gopomodoro/pkg/tray"
+	"github.com/co0p/gopomodoro/pkg/workhours"
+	"github.com/co0p/gopomodoro/pkg/xdg"
 )
 
+// commands are the subcommands that run instead of the tray.
+var commands = map[string]func(args []string) error{
+	"export": runExport,
+	"stats":  runStats,
+	"task":   runTask,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	defaults := gopomodoro.DefaultDurations()
 	silent := flag.Bool("silent", false, "disable sound notifications")
+	verbose := flag.Bool("verbose", false, "log cycle events to stderr")
+	pomodoro := flag.Duration("pomodoro", defaults.Pomodoro, "length of a pomodoro")
+	shortBreak := flag.Duration("short-break", defaults.ShortBreak, "length of a short break")
+	longBreak := flag.Duration("long-break", defaults.LongBreak, "length of a long break")
+	interval := flag.Int("long-break-interval", defaults.LongBreakInterval, "pomodoros before a long break")
+	extensionLimits := gopomodoro.DefaultExtensionLimits()
+	maxPomodoroExtension := flag.Duration("max-pomodoro-extension", extensionLimits.Pomodoro, "how far a pomodoro can be extended in total (0 disables extending)")
+	maxShortBreakExtension := flag.Duration("max-short-break-extension", extensionLimits.ShortBreak, "how far a short break can be extended in total (0 disables extending)")
+	maxLongBreakExtension := flag.Duration("max-long-break-extension", extensionLimits.LongBreak, "how far a long break can be extended in total (0 disables extending)")
+	autoStartBreaks := flag.Bool("auto-start-breaks", true, "start breaks without confirmation")
+	autoStartPomodoros := flag.Bool("auto-start-pomodoros", true, "start pomodoros after a break without confirmation")
+	task := flag.String("task", "", "label pomodoros with the task being worked on")
+	tags := flag.String("tags", "", "comma-separated tags for the task")
+	scheduleName := flag.String("schedule", "", "built-in schedule ("+strings.Join(schedule.Names(), ", ")+") or path to a schedule file; overrides the durations")
+	dailyGoal := flag.Int("daily-goal", 0, "pomodoros to complete per day (0 disables the goal)")
+	weeklyGoal := flag.Int("weekly-goal", 0, "pomodoros to complete per week (0 disables the goal)")
+	dayStart := flag.Int("day-start", 0, "hour at which a day begins for goals (0-23)")
+	profileName := flag.String("profile", "", "start with the named profile; flags given explicitly override its settings")
+	calendarPath := flag.String("calendar", "", "path to an iCalendar (.ics) file with meetings to keep pomodoros out of")
+	calendarPolicy := flag.String("calendar-policy", calendar.Shorten.String(), "what to do with a pomodoro that would overlap a meeting: shorten, warn or refuse")
+	calendarAction := flag.String("calendar-action", calendar.Pause.String(), "what to do with a running cycle when a meeting begins: pause or end")
+	minBreak := flag.Float64("min-break", 0, "share of each break (0-1) that must pass before the next pomodoro, even after Stop (0 disables)")
+	strict := flag.Bool("strict", false, "enforce indivisible pomodoros: no pausing, and stopping needs confirmation")
+	idleThreshold := flag.Duration("idle-threshold", idle.DefaultThreshold, "how long you may be idle before a pomodoro is paused or voided (0 disables)")
+	idleAction := flag.String("idle-action", idle.Pause.String(), "what to do with a pomodoro once you are idle: pause or void")
 	flag.Parse()
 
+	if *pomodoro <= 0 || *shortBreak <= 0 || *longBreak <= 0 || *interval <= 0 {
+		log.Fatal("durations and long break interval must be positive")
+	}
+	if *dailyGoal < 0 || *weeklyGoal < 0 || *dayStart < 0 || *dayStart > 23 {
+		log.Fatal("goals must not be negative and the day must start between 0 and 23")
+	}
+	if *minBreak < 0 || *minBreak > 1 {
+		log.Fatal("the mandatory share of a break must be between 0 and 1")
+	}
+
+	policy, err := calendar.ParsePolicy(*calendarPolicy)
+	if err != nil {
+		log.Fatal(err)
+	}
+	action, err := calendar.ParseAction(*calendarAction)
+	if err != nil {
+		log.Fatal(err)
+	}
+	onIdle, err := idle.ParseAction(*idleAction)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var sched gopomodoro.Schedule
+	if *scheduleName != "" {
+		if sched, err = loadSchedule(*scheduleName); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	configDir, err := xdg.ConfigDir()
+	if err != nil {
+		log.Fatal(err)
+	}
+	profiles, err := profile.Load(filepath.Join(configDir, profile.FileName))
+	if err != nil {
+		log.Fatal(err)
+	}
+	explicit := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	// lookupProfile finds a profile by name, overriding its settings with the
+	// flags given on the command line.
+	lookupProfile := func(name string) (gopomodoro.Profile, bool) {
+		p, ok := profile.Find(profiles, name)
+		if explicit["pomodoro"] {
+			p.Durations.Pomodoro = *pomodoro
+		}
+		if explicit["short-break"] {
+			p.Durations.ShortBreak = *shortBreak
+		}
+		if explicit["long-break"] {
+			p.Durations.LongBreak = *longBreak
+		}
+		if explicit["long-break-interval"] {
+			p.Durations.LongBreakInterval = *interval
+		}
+		if explicit["schedule"] {
+			p.Schedule = sched
+		}
+		if explicit["auto-start-breaks"] {
+			p.ConfirmBreaks = !*autoStartBreaks
+		}
+		if explicit["auto-start-pomodoros"] {
+			p.ConfirmPomodoros = !*autoStartPomodoros
+		}
+		if explicit["silent"] {
+			p.Silent = *silent
+		}
+		return p, ok
+	}
+
 	t := ticker.New()
 
 	var notifier gopomodoro.Notifier
@@ -24,11 +152,181 @@ func main() {
 	c := &gopomodoro.Cycle{
 		Ticker:   t,
 		Notifier: notifier,
+		Durations: gopomodoro.Durations{
+			Pomodoro:          *pomodoro,
+			ShortBreak:        *shortBreak,
+			LongBreak:         *longBreak,
+			LongBreakInterval: *interval,
+		},
+		Schedule:         sched,
+		ConfirmBreaks:    !*autoStartBreaks,
+		ConfirmPomodoros: !*autoStartPomodoros,
+		ExtensionLimits: gopomodoro.ExtensionLimits{
+			Pomodoro:   extensionLimit(*maxPomodoroExtension),
+			ShortBreak: extensionLimit(*maxShortBreakExtension),
+			LongBreak:  extensionLimit(*maxLongBreakExtension),
+		},
+		Strict:      *strict,
+		BreakPolicy: gopomodoro.BreakPolicy{MinPortion: *minBreak},
+		Goals: gopomodoro.Goals{
+			Daily:    *dailyGoal,
+			Weekly:   *weeklyGoal,
+			DayStart: time.Duration(*dayStart) * time.Hour,
+		},
+	}
+	// gates decide whether pomodoros may start; drivers start, pause and
+	// stop the cycle on their own once the tray runs.
+	var gates gopomodoro.StartGates
+	var drivers []interface{ Run() }
+	hours, err := workhours.Load(filepath.Join(configDir, workhours.FileName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		log.Fatal(err)
+	default:
+		gates = append(gates, hours)
+		drivers = append(drivers, &workhours.Driver{Cycle: c, Hours: hours, Ticker: ticker.New()})
+	}
+	if *calendarPath != "" {
+		onError := func(err error) { log.Printf("reading calendar: %v", err) }
+		cal := &calendar.File{Path: *calendarPath, Policy: policy, OnError: onError}
+		gates = append(gates, cal)
+		drivers = append(drivers, &calendar.Driver{Cycle: c, Calendar: cal, Action: action, Ticker: ticker.New(), OnError: onError})
+	}
+	if source := idleSource(); source != nil && *idleThreshold > 0 {
+		onError := func(err error) { log.Printf("detecting idleness: %v", err) }
+		drivers = append(drivers, &idle.Driver{Cycle: c, Source: source, Action: onIdle, Threshold: *idleThreshold, Ticker: ticker.New(), OnError: onError})
+	}
+	if len(gates) > 0 {
+		c.Gate = gates
 	}
 	tr := tray.New(c)
+	// The Profile menu switches to the same settings --profile would.
+	tr.Profiles = make([]gopomodoro.Profile, len(profiles))
+	for i, p := range profiles {
+		tr.Profiles[i], _ = lookupProfile(p.Name)
+	}
 	c.Observer = tr
 
+	if *verbose {
+		c.Subscribe(gopomodoro.EventSubscriberFunc(logEvent))
+	}
+
+	dataDir, err := xdg.DataDir()
+	if err != nil {
+		log.Fatal(err)
+	}
+	tasks := &inventory.Store{Path: filepath.Join(dataDir, inventory.FileName)}
+	tr.Tasks = tasks
+	c.Subscribe(&inventory.Tracker{
+		Store:   tasks,
+		OnError: func(err error) { log.Printf("counting pomodoro: %v", err) },
+	})
+	hist := &history.Log{Path: filepath.Join(dataDir, history.FileName)}
+	c.Subscribe(&history.Recorder{
+		Log:     hist,
+		OnError: func(err error) { log.Printf("recording history: %v", err) },
+	})
+	seedProgress(c, hist)
+
+	stateDir, err := xdg.StateDir()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *profileName != "" {
+		p, ok := lookupProfile(*profileName)
+		if !ok {
+			log.Fatalf("unknown profile %q", *profileName)
+		}
+		c.SetProfile(p)
+		lookupProfile = nil
+	}
+	restore(c, &journal.Journal{Path: filepath.Join(stateDir, journal.FileName)}, lookupProfile)
+	if *task != "" || *tags != "" {
+		c.SetTask(parseTask(*task, *tags))
+	}
+
+	for _, d := range drivers {
+		go d.Run()
+	}
+
 	if err := tr.Run(); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// loadSchedule returns the built-in schedule called name, or else reads the
+// schedule file at name.
+func loadSchedule(name string) (gopomodoro.Schedule, error) {
+	if s, ok := schedule.Builtin(name); ok {
+		return s, nil
+	}
+	return schedule.Load(name)
+}
+
+// restore continues the cycle saved by a previous run, if any, and keeps the
+// journal up to date from now on. The saved profile is looked up and applied
+// first unless lookupProfile is nil.
+func restore(c *gopomodoro.Cycle, j *journal.Journal, lookupProfile func(string) (gopomodoro.Profile, bool)) {
+	cp, err := j.Load()
+	c.Subscribe(&journal.Recorder{
+		Cycle:   c,
+		Journal: j,
+		OnError: func(err error) { log.Printf("saving state: %v", err) },
+	})
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		log.Printf("ignoring saved state: %v", err)
+	default:
+		if lookupProfile != nil && cp.Profile != "" {
+			if p, ok := lookupProfile(cp.Profile); ok {
+				c.SetProfile(p)
+			}
+		}
+		c.Restore(cp)
+	}
+}
+
+// parseTask builds a task from a name and comma-separated tags.
+func parseTask(name, tags string) gopomodoro.Task {
+	t := gopomodoro.Task{Name: strings.TrimSpace(name)}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+	return t
+}
+
+// seedProgress counts the pomodoros completed today and this week from the
+// history, so goals carry over across restarts.
+func seedProgress(c *gopomodoro.Cycle, hist *history.Log) {
+	now := time.Now()
+	periods := stats.Periods{DayStart: c.Goals.DayStart}
+	records, err := hist.Query(periods.Since(now), time.Time{})
+	if err != nil {
+		log.Printf("reading history: %v", err)
+		return
+	}
+	report := periods.Report(records, now)
+	c.SetProgress(report.Today.Completed, report.Week.Completed)
+}
+
+// extensionLimit turns the limit given on the command line into an
+// ExtensionLimits field, in which zero means the default and a negative
+// limit disables extending.
+func extensionLimit(d time.Duration) time.Duration {
+	if d <= 0 {
+		return -1
+	}
+	return d
+}
+
+// logEvent writes every cycle event except ticks to the standard logger.
+func logEvent(e gopomodoro.Event) {
+	if e.Type == gopomodoro.Ticked {
+		return
+	}
+	log.Printf("%s %s pomodoro=%d remaining=%s task=%q", e.Type, e.Phase, e.Pomodoro, e.Remaining, e.Task)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/history"
+	"github.com/co0p/gopomodoro/pkg/stats"
+	"github.com/co0p/gopomodoro/pkg/xdg"
+)
+
+// runStats implements `gopomodoro stats`, printing totals from the session
+// history.
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	dayStart := fs.Int("day-start", 0, "hour at which a day begins (0-23)")
+	fs.Parse(args)
+
+	if *dayStart < 0 || *dayStart > 23 {
+		return fmt.Errorf("day start must be an hour between 0 and 23")
+	}
+
+	dataDir, err := xdg.DataDir()
+	if err != nil {
+		return err
+	}
+	log := &history.Log{Path: filepath.Join(dataDir, history.FileName)}
+
+	now := time.Now()
+	periods := stats.Periods{DayStart: time.Duration(*dayStart) * time.Hour}
+	records, err := log.Query(periods.Since(now), time.Time{})
+	if err != nil {
+		return err
+	}
+
+	report := periods.Report(records, now)
+	if *asJSON {
+		return stats.WriteJSON(os.Stdout, report)
+	}
+	return stats.WriteText(os.Stdout, report)
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/inventory"
+	"github.com/co0p/gopomodoro/pkg/xdg"
+)
+
+const taskUsage = `usage:
+  gopomodoro task add [-estimate N] [-tags a,b] [-today | -urgent] NAME
+  gopomodoro task list [-all]
+  gopomodoro task today ID
+  gopomodoro task done ID
+  gopomodoro task estimate ID POMODOROS
+  gopomodoro task report`
+
+// runTask implements `gopomodoro task`, managing the Activity Inventory and
+// the To Do Today sheet.
+func runTask(args []string) error {
+	if len(args) == 0 {
+		return errors.New(taskUsage)
+	}
+	store, err := openInventory()
+	if err != nil {
+		return err
+	}
+	switch args[0] {
+	case "add":
+		return addTask(store, args[1:])
+	case "list":
+		return listTasks(store, args[1:])
+	case "today":
+		id, err := taskID(args[1:])
+		if err != nil {
+			return err
+		}
+		return store.MoveToToday(id)
+	case "done":
+		id, err := taskID(args[1:])
+		if err != nil {
+			return err
+		}
+		return store.Done(id)
+	case "estimate":
+		if len(args) != 3 {
+			return errors.New(taskUsage)
+		}
+		id, err := taskID(args[1:2])
+		if err != nil {
+			return err
+		}
+		estimate, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid estimate %q", args[2])
+		}
+		return store.SetEstimate(id, estimate)
+	case "report":
+		return reportAccuracy(store)
+	default:
+		return errors.New(taskUsage)
+	}
+}
+
+func openInventory() (*inventory.Store, error) {
+	dataDir, err := xdg.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return &inventory.Store{Path: filepath.Join(dataDir, inventory.FileName)}, nil
+}
+
+func addTask(store *inventory.Store, args []string) error {
+	fs := flag.NewFlagSet("task add", flag.ExitOnError)
+	estimate := fs.Int("estimate", 0, "pomodoros the task is expected to take")
+	tags := fs.String("tags", "", "comma-separated tags")
+	today := fs.Bool("today", false, "plan the task for today")
+	urgent := fs.Bool("urgent", false, "add the task as unplanned and urgent")
+	fs.Parse(args)
+
+	section := inventory.Inventory
+	switch {
+	case *today && *urgent:
+		return errors.New("a task is either planned for today or urgent")
+	case *today:
+		section = inventory.Today
+	case *urgent:
+		section = inventory.Urgent
+	}
+	task := parseTask(strings.Join(fs.Args(), " "), *tags)
+	item, err := store.Add(task.Name, task.Tags, *estimate, section)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("added %d\n", item.ID)
+	return nil
+}
+
+func listTasks(store *inventory.Store, args []string) error {
+	fs := flag.NewFlagSet("task list", flag.ExitOnError)
+	all := fs.Bool("all", false, "include finished tasks")
+	fs.Parse(args)
+
+	items, err := store.Items()
+	if err != nil {
+		return err
+	}
+	for _, section := range []struct {
+		title   string
+		section inventory.Section
+	}{
+		{"To Do Today", inventory.Today},
+		{"Unplanned & Urgent", inventory.Urgent},
+		{"Activity Inventory", inventory.Inventory},
+	} {
+		printSection(os.Stdout, section.title, section.section, items, *all)
+	}
+	return nil
+}
+
+func printSection(w io.Writer, title string, section inventory.Section, items []inventory.Item, all bool) {
+	fmt.Fprintln(w, title)
+	for _, item := range items {
+		if item.Section != section || item.IsDone() && !all {
+			continue
+		}
+		mark := " "
+		if item.IsDone() {
+			mark = "x"
+		}
+		line := fmt.Sprintf("  [%s] %3d  %s", mark, item.ID, item.Task())
+		switch {
+		case item.Estimate > 0:
+			line += fmt.Sprintf(" (%d/%d)", item.Actual, item.Estimate)
+		case item.Actual > 0:
+			line += fmt.Sprintf(" (%d)", item.Actual)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func taskID(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errors.New(taskUsage)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid task id %q", args[0])
+	}
+	return id, nil
+}
+
+// reportAccuracy prints how finished tasks compared to their estimates, per
+// week, followed by the open tasks that already exceed theirs.
+func reportAccuracy(store *inventory.Store) error {
+	items, err := store.Items()
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Week of\tTasks\tEstimated\tActual\tRatio\tWithin estimate")
+	for _, week := range inventory.WeeklyAccuracy(items) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f\t%d/%d\n",
+			week.From.Format(time.DateOnly),
+			week.Tasks,
+			week.Estimated,
+			week.Actual,
+			week.Ratio(),
+			week.WithinEstimate,
+			week.Tasks,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var over []inventory.Item
+	for _, item := range items {
+		if !item.IsDone() && item.Estimate > 0 && item.Actual > item.Estimate {
+			over = append(over, item)
+		}
+	}
+	if len(over) > 0 {
+		fmt.Println()
+		fmt.Println("Over estimate")
+		for _, item := range over {
+			fmt.Printf("  %3d  %s (%d/%d)\n", item.ID, item.Task(), item.Actual, item.Estimate)
+		}
+	}
+	return nil
+}
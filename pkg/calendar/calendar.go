@@ -0,0 +1,155 @@
+// Package calendar makes the cycle aware of meetings in an iCalendar (.ics)
+// file: pomodoros that would overlap an event are shortened, refused or
+// started with a warning, and a running cycle is paused or ended when an
+// event begins.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// DefaultMinPomodoro is the shortest pomodoro Shorten leaves.
+const DefaultMinPomodoro = 10 * time.Minute
+
+// ErrConflict is returned when a pomodoro is refused because of an event.
+var ErrConflict = errors.New("conflicts with a calendar event")
+
+// Policy decides what happens to a pomodoro that would overlap an event.
+type Policy int
+
+const (
+	// Shorten ends the pomodoro when the event begins, or skips it when
+	// less than the minimum would be left.
+	Shorten Policy = iota
+	// Warn starts the pomodoro anyway, with a warning.
+	Warn
+	// Refuse does not start the pomodoro.
+	Refuse
+)
+
+var policyNames = []string{"shorten", "warn", "refuse"}
+
+func (p Policy) String() string {
+	if p >= 0 && int(p) < len(policyNames) {
+		return policyNames[p]
+	}
+	return fmt.Sprintf("Policy(%d)", int(p))
+}
+
+// ParsePolicy returns the policy called name.
+func ParsePolicy(name string) (Policy, error) {
+	for i, n := range policyNames {
+		if n == name {
+			return Policy(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown calendar policy %q", name)
+}
+
+// File is a calendar kept in an .ics file. It is re-read whenever it
+// changed on disk, so a calendar exported again is picked up while running.
+type File struct {
+	Path   string
+	Policy Policy
+
+	// MinPomodoro is the shortest pomodoro Shorten leaves; the zero value
+	// uses DefaultMinPomodoro.
+	MinPomodoro time.Duration
+
+	// OnError is optional; it receives errors reading the file when
+	// admitting a pomodoro, which is then admitted as if the calendar were
+	// empty.
+	OnError func(error)
+
+	mu      sync.Mutex
+	cached  *Calendar
+	modTime time.Time
+	size    int64
+}
+
+// Events returns the events that overlap [from, to), ordered by start.
+func (f *File) Events(from, to time.Time) ([]Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return c.Between(from, to), nil
+}
+
+// AdmitPomodoro applies the policy to a pomodoro of length d starting at t
+// if it would overlap an event. A pomodoro starting during an event is
+// warned about under Warn and refused otherwise.
+func (f *File) AdmitPomodoro(t time.Time, d time.Duration) (gopomodoro.Admission, error) {
+	events, err := f.Events(t, t.Add(d))
+	if err != nil {
+		if f.OnError != nil {
+			f.OnError(err)
+		}
+		return gopomodoro.Admit(d), nil
+	}
+	if len(events) == 0 {
+		return gopomodoro.Admit(d), nil
+	}
+
+	e := events[0]
+	conflict := describe(e)
+	left := e.Start.Sub(t)
+	switch {
+	case f.Policy == Warn:
+		return gopomodoro.Admission{Length: d, Warning: "overlaps " + conflict}, nil
+	case f.Policy == Shorten && left >= f.minPomodoro():
+		return gopomodoro.Admission{
+			Length:  left,
+			Warning: fmt.Sprintf("shortened to %dm before %s", int(left.Minutes()), conflict),
+		}, nil
+	default:
+		return gopomodoro.Admission{}, fmt.Errorf("%w: %s", ErrConflict, conflict)
+	}
+}
+
+func (f *File) minPomodoro() time.Duration {
+	if f.MinPomodoro > 0 {
+		return f.MinPomodoro
+	}
+	return DefaultMinPomodoro
+}
+
+// load returns the calendar, reading the file again if it changed. Must be
+// called with mu held.
+func (f *File) load() (*Calendar, error) {
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+	if f.cached != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.cached, nil
+	}
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+	defer file.Close()
+	c, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.Path, err)
+	}
+	f.cached, f.modTime, f.size = c, info.ModTime(), info.Size()
+	return c, nil
+}
+
+// describe names an event and when it starts, e.g. "Stand-up at 10:00".
+func describe(e Event) string {
+	summary := e.Summary
+	if summary == "" {
+		summary = "an event"
+	}
+	return summary + " at " + e.Start.Format("15:04")
+}
@@ -0,0 +1,107 @@
+package calendar_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/calendar"
+)
+
+const standUp = "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:standup\r\nSUMMARY:Stand-up\r\n" +
+	"DTSTART:20260105T100000Z\r\nDTEND:20260105T101500Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func writeCalendar(t *testing.T, ics string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "work.ics")
+	if err := os.WriteFile(path, []byte(ics), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFile_GivenNoEventAhead_WhenAdmitting_ThenRunsInFull(t *testing.T) {
+	f := &calendar.File{Path: writeCalendar(t, standUp)}
+
+	a, err := f.AdmitPomodoro(monday.Add(10*time.Hour+15*time.Minute), 25*time.Minute)
+
+	if err != nil || a.Length != 25*time.Minute || a.Warning != "" {
+		t.Fatalf("expected a full pomodoro, got %+v, %v", a, err)
+	}
+}
+
+func TestFile_GivenEventAhead_WhenAdmitting_ThenPolicyApplies(t *testing.T) {
+	path := writeCalendar(t, standUp)
+	for _, tc := range []struct {
+		name    string
+		policy  calendar.Policy
+		start   time.Duration
+		length  time.Duration
+		warning string
+		refused bool
+	}{
+		{"shorten", calendar.Shorten, 9*time.Hour + 45*time.Minute, 15 * time.Minute, "shortened to 15m before Stand-up at 10:00", false},
+		{"skip when too short", calendar.Shorten, 9*time.Hour + 55*time.Minute, 0, "", true},
+		{"warn", calendar.Warn, 9*time.Hour + 45*time.Minute, 25 * time.Minute, "overlaps Stand-up at 10:00", false},
+		{"refuse", calendar.Refuse, 9*time.Hour + 45*time.Minute, 0, "", true},
+		{"during event", calendar.Shorten, 10*time.Hour + 5*time.Minute, 0, "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &calendar.File{Path: path, Policy: tc.policy}
+
+			a, err := f.AdmitPomodoro(monday.Add(tc.start), 25*time.Minute)
+
+			if tc.refused {
+				if !errors.Is(err, calendar.ErrConflict) {
+					t.Fatalf("expected ErrConflict, got %+v, %v", a, err)
+				}
+				return
+			}
+			if err != nil || a.Length != tc.length || a.Warning != tc.warning {
+				t.Fatalf("expected %v with %q, got %+v, %v", tc.length, tc.warning, a, err)
+			}
+		})
+	}
+}
+
+func TestFile_GivenFileChanged_WhenQueried_ThenReadsItAgain(t *testing.T) {
+	path := writeCalendar(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	f := &calendar.File{Path: path}
+	if events, _ := f.Events(monday, monday.AddDate(0, 0, 1)); len(events) != 0 {
+		t.Fatalf("expected an empty calendar, got %+v", events)
+	}
+
+	_ = os.WriteFile(path, []byte(standUp), 0o644)
+	events, err := f.Events(monday, monday.AddDate(0, 0, 1))
+
+	if err != nil || len(events) != 1 {
+		t.Fatalf("expected the stand-up after the change, got %+v, %v", events, err)
+	}
+}
+
+func TestFile_GivenMissingFile_WhenAdmitting_ThenReportsAndAdmits(t *testing.T) {
+	var reported error
+	f := &calendar.File{
+		Path:    filepath.Join(t.TempDir(), "missing.ics"),
+		OnError: func(err error) { reported = err },
+	}
+
+	a, err := f.AdmitPomodoro(monday, 25*time.Minute)
+
+	if err != nil || a.Length != 25*time.Minute || !errors.Is(reported, os.ErrNotExist) {
+		t.Fatalf("expected admission and a reported error, got %+v, %v, %v", a, err, reported)
+	}
+}
+
+func TestParsePolicy_GivenNames_WhenParsed_ThenRoundTrips(t *testing.T) {
+	for _, p := range []calendar.Policy{calendar.Shorten, calendar.Warn, calendar.Refuse} {
+		if parsed, err := calendar.ParsePolicy(p.String()); err != nil || parsed != p {
+			t.Fatalf("expected %v, got %v, %v", p, parsed, err)
+		}
+	}
+	if _, err := calendar.ParsePolicy("ignore"); err == nil {
+		t.Fatal("expected an unknown policy to fail")
+	}
+}
@@ -0,0 +1,101 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/internal/poll"
+)
+
+// Action is what Driver does to a running cycle when an event begins.
+type Action int
+
+const (
+	// Pause pauses the running phase.
+	Pause Action = iota
+	// End stops the cycle, voiding a running pomodoro.
+	End
+)
+
+var actionNames = []string{"pause", "end"}
+
+func (a Action) String() string {
+	if a >= 0 && int(a) < len(actionNames) {
+		return actionNames[a]
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// ParseAction returns the action called name.
+func ParseAction(name string) (Action, error) {
+	for i, n := range actionNames {
+		if n == name {
+			return Action(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown calendar action %q", name)
+}
+
+// Driver pauses or ends the running cycle whenever an event begins. A
+// pomodoro of a strict cycle is ended, as it cannot be paused.
+type Driver struct {
+	Cycle    *gopomodoro.Cycle
+	Calendar *File
+	Action   Action
+
+	// Clock is optional; the system clock is used when nil.
+	Clock gopomodoro.Clock
+
+	// Ticker paces Run.
+	Ticker gopomodoro.Ticker
+
+	// OnError is optional; it receives errors reading the calendar, each
+	// one once until the calendar can be read again.
+	OnError func(error)
+
+	// checked is when Check last looked for events.
+	checked time.Time
+	errors  poll.Errors
+}
+
+// Run checks the calendar on every tick of the Ticker. It blocks forever.
+func (d *Driver) Run() {
+	poll.Run(d.Ticker, d.Check)
+}
+
+// Check acts on the cycle if an event began since the previous Check. The
+// first Check only notes the time, so events already under way when the
+// process starts are left alone.
+func (d *Driver) Check() {
+	now := poll.Now(d.Clock)
+	since := d.checked
+	d.checked = now
+	if since.IsZero() {
+		return
+	}
+
+	events, err := d.Calendar.Events(since, now.Add(time.Nanosecond))
+	if d.errors.Report(err, d.OnError) {
+		return
+	}
+	for _, e := range events {
+		if e.Start.After(since) && !e.Start.After(now) {
+			d.act(e)
+			return
+		}
+	}
+}
+
+func (d *Driver) act(e Event) {
+	s := d.Cycle.Snapshot()
+	if s.State == gopomodoro.Idle || s.Waiting {
+		return
+	}
+	// A pomodoro cannot be paused in strict mode.
+	if d.Action == End || d.Cycle.Strict && s.State == gopomodoro.Pomodoro {
+		d.Cycle.Void("meeting: " + describe(e))
+	} else {
+		d.Cycle.Pause()
+	}
+}
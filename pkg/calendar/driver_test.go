@@ -0,0 +1,101 @@
+package calendar_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/calendar"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+// cycleAt returns a cycle from pomotest.NewCycle with its clock set to at.
+func cycleAt(at time.Time, configure func(c *gopomodoro.Cycle)) (*gopomodoro.Cycle, *pomotest.MockClock, *pomotest.MockSubscriber) {
+	c, clock, subscriber := pomotest.NewCycle(configure)
+	clock.Advance(at.Sub(pomotest.StartTime))
+	return c, clock, subscriber
+}
+
+func TestDriver_GivenPomodoroRunning_WhenEventBegins_ThenPauses(t *testing.T) {
+	c, clock, _ := cycleAt(monday.Add(9*time.Hour+50*time.Minute), nil)
+	d := &calendar.Driver{Cycle: c, Calendar: &calendar.File{Path: writeCalendar(t, standUp)}, Clock: clock}
+	d.Check()
+	c.Start()
+
+	clock.Advance(5 * time.Minute)
+	d.Check()
+	if c.IsPaused() {
+		t.Fatal("expected to keep running before the event")
+	}
+	clock.Advance(5 * time.Minute)
+	d.Check()
+
+	if !c.IsPaused() {
+		t.Fatalf("expected to pause at 10:00, got %+v", c.Snapshot())
+	}
+}
+
+func TestDriver_GivenEndAction_WhenEventBegins_ThenVoidsPomodoro(t *testing.T) {
+	c, clock, subscriber := cycleAt(monday.Add(9*time.Hour+50*time.Minute), nil)
+	d := &calendar.Driver{Cycle: c, Calendar: &calendar.File{Path: writeCalendar(t, standUp)}, Clock: clock, Action: calendar.End}
+	d.Check()
+	c.Start()
+
+	clock.Advance(10 * time.Minute)
+	d.Check()
+
+	stopped, ok := subscriber.Last(gopomodoro.Stopped)
+	if !c.Is(gopomodoro.Idle) || !ok || stopped.Reason != "meeting: Stand-up at 10:00" {
+		t.Fatalf("expected the pomodoro to be voided for the stand-up, got %+v", stopped)
+	}
+}
+
+func TestDriver_GivenStrictCycle_WhenEventBegins_ThenVoidsPomodoro(t *testing.T) {
+	c, clock, subscriber := cycleAt(monday.Add(9*time.Hour+50*time.Minute), func(c *gopomodoro.Cycle) { c.Strict = true })
+	d := &calendar.Driver{Cycle: c, Calendar: &calendar.File{Path: writeCalendar(t, standUp)}, Clock: clock}
+	d.Check()
+	c.Start()
+
+	clock.Advance(10 * time.Minute)
+	d.Check()
+
+	stopped, ok := subscriber.Last(gopomodoro.Stopped)
+	if !ok || !stopped.Voided || stopped.Reason != "meeting: Stand-up at 10:00" || stopped.Elapsed != 10*time.Minute {
+		t.Fatalf("expected the pomodoro voided with the time it ran, got %+v", stopped)
+	}
+}
+
+func TestDriver_GivenEventUnderWayAtFirstCheck_WhenChecked_ThenLeavesCycleAlone(t *testing.T) {
+	c, clock, _ := cycleAt(monday.Add(10*time.Hour+5*time.Minute), nil)
+	c.Start()
+	d := &calendar.Driver{Cycle: c, Calendar: &calendar.File{Path: writeCalendar(t, standUp)}, Clock: clock}
+
+	d.Check()
+	clock.Advance(time.Second)
+	d.Check()
+
+	if c.IsPaused() {
+		t.Fatal("expected a pomodoro started during the event to keep running")
+	}
+}
+
+func TestDriver_GivenUnreadableCalendar_WhenCheckedRepeatedly_ThenReportsOnce(t *testing.T) {
+	c, clock, _ := cycleAt(monday, nil)
+	reported := 0
+	d := &calendar.Driver{
+		Cycle:    c,
+		Calendar: &calendar.File{Path: filepath.Join(t.TempDir(), "missing.ics")},
+		Clock:    clock,
+		OnError:  func(error) { reported++ },
+	}
+
+	for range 3 {
+		d.Check()
+		clock.Advance(time.Second)
+	}
+
+	if reported != 1 {
+		t.Fatalf("expected the error to be reported once, got %d", reported)
+	}
+}
@@ -0,0 +1,397 @@
+package calendar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxOccurrences bounds the expansion of a single recurring event.
+const maxOccurrences = 100000
+
+// Event is one occurrence of a calendar event.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Overlaps reports whether the event overlaps [from, to).
+func (e Event) Overlaps(from, to time.Time) bool {
+	return e.Start.Before(to) && e.End.After(from)
+}
+
+// Calendar holds the busy events of an iCalendar file. All-day, cancelled
+// and transparent (free) events are left out.
+type Calendar struct {
+	events []vevent
+
+	// overridden holds the occurrences replaced through a RECURRENCE-ID,
+	// including those replaced by a cancelled or free event.
+	overridden map[string]bool
+}
+
+// vevent is a VEVENT as parsed, before recurrences are expanded.
+type vevent struct {
+	Event
+	rule    *rule
+	exdates map[int64]bool
+
+	// duration gives the end from the start when there is no DTEND; the
+	// start may only be known once the whole event has been read.
+	duration time.Duration
+
+	// recurrenceID is set on an event that replaces one occurrence of a
+	// recurring event with the same UID.
+	recurrenceID time.Time
+}
+
+// rule is the supported subset of an RRULE: FREQ, INTERVAL, COUNT, UNTIL
+// and BYDAY without ordinals.
+type rule struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	byDay    map[time.Weekday]bool
+}
+
+// Parse reads the events of an iCalendar stream. Recurring events with
+// rules outside the supported subset keep only their first occurrence.
+func Parse(r io.Reader) (*Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+
+	c := &Calendar{overridden: map[string]bool{}}
+	var current *vevent
+	var skip bool
+	for n, line := range lines {
+		name, params, value := splitProperty(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current, skip = &vevent{exdates: map[int64]bool{}}, false
+			continue
+		case name == "END" && value == "VEVENT":
+			if current != nil && !current.recurrenceID.IsZero() {
+				c.overridden[occurrenceKey(current.UID, current.recurrenceID)] = true
+			}
+			if current != nil && !skip && !current.Start.IsZero() {
+				if current.End.IsZero() {
+					current.End = current.Start.Add(current.duration)
+				}
+				c.events = append(c.events, *current)
+			}
+			current = nil
+			continue
+		case current == nil:
+			continue
+		}
+
+		switch name {
+		case "UID":
+			current.UID = value
+		case "SUMMARY":
+			current.Summary = unescape(value)
+		case "DTSTART":
+			if params["VALUE"] == "DATE" {
+				skip = true
+				continue
+			}
+			current.Start, err = parseDateTime(value, params["TZID"])
+		case "DTEND":
+			current.End, err = parseDateTime(value, params["TZID"])
+		case "DURATION":
+			current.duration, err = parseDuration(value)
+		case "RRULE":
+			current.rule, err = parseRule(value)
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				var t time.Time
+				if t, err = parseDateTime(v, params["TZID"]); err != nil {
+					break
+				}
+				current.exdates[t.Unix()] = true
+			}
+		case "RECURRENCE-ID":
+			current.recurrenceID, err = parseDateTime(value, params["TZID"])
+		case "STATUS":
+			skip = skip || value == "CANCELLED"
+		case "TRANSP":
+			skip = skip || value == "TRANSPARENT"
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse calendar: line %d: %s: %w", n+1, name, err)
+		}
+	}
+	return c, nil
+}
+
+// Between returns the occurrences that overlap [from, to), ordered by start.
+func (c *Calendar) Between(from, to time.Time) []Event {
+	var events []Event
+	for _, e := range c.events {
+		if e.rule == nil || !e.recurrenceID.IsZero() {
+			if e.Overlaps(from, to) {
+				events = append(events, e.Event)
+			}
+			continue
+		}
+		length := e.End.Sub(e.Start)
+		e.rule.each(e.Start, from.Add(-length), func(start time.Time) bool {
+			if !start.Before(to) {
+				return false
+			}
+			occurrence := Event{UID: e.UID, Summary: e.Summary, Start: start, End: start.Add(length)}
+			if !e.exdates[start.Unix()] && !c.overridden[occurrenceKey(e.UID, start)] && occurrence.Overlaps(from, to) {
+				events = append(events, occurrence)
+			}
+			return true
+		})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	return events
+}
+
+func occurrenceKey(uid string, start time.Time) string {
+	return uid + "@" + strconv.FormatInt(start.Unix(), 10)
+}
+
+// each calls yield with the start of every occurrence, first one first,
+// until yield returns false or the rule ends. Without a COUNT, occurrences
+// well before after are skipped without calling yield. Occurrences keep the
+// wall clock time of the first one across daylight saving changes.
+func (r *rule) each(first, after time.Time, yield func(time.Time) bool) {
+	y, m, d := first.Date()
+	hh, mm, ss := first.Clock()
+	at := func(dy, dm, dd int) time.Time {
+		return time.Date(y+dy, m+time.Month(dm), d+dd, hh, mm, ss, 0, first.Location())
+	}
+
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if t.Before(first) {
+			return true
+		}
+		if !r.until.IsZero() && t.After(r.until) {
+			return false
+		}
+		if r.count > 0 && emitted >= r.count {
+			return false
+		}
+		emitted++
+		return yield(t)
+	}
+
+	start := 0
+	if r.count == 0 && after.After(first) {
+		days := int(after.Sub(first).Hours() / 24)
+		periods := map[string]int{"DAILY": days, "WEEKLY": days / 7, "MONTHLY": days / 31, "YEARLY": days / 366}
+		start = max(0, periods[r.freq]/r.interval-1)
+	}
+	for i := start; i < start+maxOccurrences; i++ {
+		n := i * r.interval
+		switch r.freq {
+		case "DAILY":
+			t := at(0, 0, n)
+			if len(r.byDay) > 0 && !r.byDay[t.Weekday()] {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		case "WEEKLY":
+			monday := -(int(first.Weekday()) + 6) % 7
+			for offset := 0; offset < 7; offset++ {
+				t := at(0, 0, 7*n+monday+offset)
+				if len(r.byDay) == 0 && offset != -monday || len(r.byDay) > 0 && !r.byDay[t.Weekday()] {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+			}
+		case "MONTHLY", "YEARLY":
+			var t time.Time
+			if r.freq == "MONTHLY" {
+				t = at(0, n, 0)
+			} else {
+				t = at(n, 0, 0)
+			}
+			// Skip months without the day, such as February 30th.
+			if t.Day() != d {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		default:
+			yield(first)
+			return
+		}
+	}
+}
+
+func parseRule(value string) (*rule, error) {
+	r := &rule{interval: 1}
+	supported := true
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		var err error
+		switch key {
+		case "FREQ":
+			r.freq = val
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(val)
+			if err == nil && r.interval < 1 {
+				err = errors.New("interval must be positive")
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(val)
+		case "UNTIL":
+			r.until, err = parseDateTime(val, "")
+			if len(val) == len("20060102") {
+				// A date includes the whole day.
+				r.until = r.until.AddDate(0, 0, 1).Add(-time.Second)
+			}
+		case "BYDAY":
+			r.byDay = map[time.Weekday]bool{}
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdays[code]
+				if !ok {
+					// Ordinals such as 1MO are not supported.
+					supported = false
+					break
+				}
+				r.byDay[day] = true
+			}
+		case "WKST":
+		default:
+			// Other parts, such as BYMONTHDAY or BYSETPOS, are not
+			// supported.
+			supported = false
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !supported {
+		// Keep the first occurrence only.
+		r.freq = ""
+	}
+	return r, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseDateTime parses a DATE-TIME in UTC ("...Z"), in the time zone tzid
+// or in local time, or a DATE as midnight.
+func parseDateTime(value, tzid string) (time.Time, error) {
+	loc := time.Local
+	if tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+	if len(value) == len("20060102") {
+		return time.ParseInLocation("20060102", value, loc)
+	}
+	return time.ParseInLocation("20060102T150405", value, loc)
+}
+
+// parseDuration parses a DURATION such as PT30M, PT1H30M, P1D or P1W.
+func parseDuration(value string) (time.Duration, error) {
+	s, negative := strings.CutPrefix(value, "-")
+	s = strings.TrimPrefix(s, "+")
+	s, ok := strings.CutPrefix(s, "P")
+	if !ok {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var d time.Duration
+	number := ""
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch >= '0' && ch <= '9':
+			number += string(ch)
+		case ch == 'T':
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		default:
+			unit, ok := units[ch]
+			n, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			d += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// unfold reads content lines, joining folded continuation lines.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitProperty splits a content line such as
+// "DTSTART;TZID=Europe/Berlin:20260105T090000" into its upper-cased name,
+// parameters and value.
+func splitProperty(line string) (name string, params map[string]string, value string) {
+	inQuotes := false
+	colon := -1
+	for i, ch := range line {
+		if ch == '"' {
+			inQuotes = !inQuotes
+		}
+		if ch == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return strings.ToUpper(line), nil, ""
+	}
+	parts := strings.Split(line[:colon], ";")
+	params = map[string]string{}
+	for _, p := range parts[1:] {
+		key, val, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:]
+}
+
+// unescape resolves the backslash escapes of a TEXT value.
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
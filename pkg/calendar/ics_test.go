@@ -0,0 +1,147 @@
+package calendar_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/calendar"
+)
+
+// monday is a Monday.
+var monday = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+func parse(t *testing.T, events ...string) *calendar.Calendar {
+	t.Helper()
+	ics := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(events, "") + "END:VCALENDAR\r\n"
+	c, err := calendar.Parse(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return c
+}
+
+func starts(events []calendar.Event) []string {
+	var s []string
+	for _, e := range events {
+		s = append(s, e.Start.UTC().Format("Mon 02 15:04"))
+	}
+	return s
+}
+
+func TestParse_GivenSingleEvent_WhenQueried_ThenFoundWithSummary(t *testing.T) {
+	c := parse(t, "BEGIN:VEVENT\r\nUID:1\r\nSUMMARY:Design review\\, part 2\r\n"+
+		"DTSTART:20260105T100000Z\r\nDTEND:20260105T110000Z\r\nEND:VEVENT\r\n")
+
+	events := c.Between(monday.Add(9*time.Hour), monday.Add(10*time.Hour+time.Minute))
+
+	if len(events) != 1 || events[0].Summary != "Design review, part 2" || !events[0].End.Equal(monday.Add(11*time.Hour)) {
+		t.Fatalf("expected the design review, got %+v", events)
+	}
+	if len(c.Between(monday.Add(11*time.Hour), monday.Add(12*time.Hour))) != 0 {
+		t.Fatal("expected nothing after the event ended")
+	}
+}
+
+func TestParse_GivenTimeZoneFoldingAndDuration_WhenQueried_ThenResolved(t *testing.T) {
+	c := parse(t, "BEGIN:VEVENT\r\nUID:1\r\nSUMMARY:A very long\r\n  meeting title\r\n"+
+		"DTSTART;TZID=Europe/Berlin:20260105T100000\r\nDURATION:PT1H30M\r\nEND:VEVENT\r\n")
+
+	events := c.Between(monday, monday.AddDate(0, 0, 1))
+
+	if len(events) != 1 || events[0].Summary != "A very long meeting title" {
+		t.Fatalf("expected one unfolded event, got %+v", events)
+	}
+	if !events[0].Start.Equal(monday.Add(9*time.Hour)) || events[0].End.Sub(events[0].Start) != 90*time.Minute {
+		t.Fatalf("expected 09:00-10:30 UTC, got %v-%v", events[0].Start.UTC(), events[0].End.UTC())
+	}
+}
+
+func TestParse_GivenWeeklyStandUp_WhenQueried_ThenExpandsWithExceptionsAndOverrides(t *testing.T) {
+	c := parse(t,
+		"BEGIN:VEVENT\r\nUID:standup\r\nSUMMARY:Stand-up\r\nDTSTART:20260105T093000Z\r\nDTEND:20260105T094500Z\r\n"+
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR\r\nEXDATE:20260107T093000Z\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:standup\r\nRECURRENCE-ID:20260109T093000Z\r\nSUMMARY:Stand-up (moved)\r\n"+
+			"DTSTART:20260109T110000Z\r\nDTEND:20260109T111500Z\r\nEND:VEVENT\r\n",
+	)
+
+	events := c.Between(monday, monday.AddDate(0, 0, 14))
+
+	expected := []string{"Mon 05 09:30", "Fri 09 11:00", "Mon 12 09:30", "Wed 14 09:30", "Fri 16 09:30"}
+	if got := starts(events); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestParse_GivenCancelledOverride_WhenQueried_ThenOccurrenceLeftOut(t *testing.T) {
+	c := parse(t,
+		"BEGIN:VEVENT\r\nUID:standup\r\nSUMMARY:Stand-up\r\nDTSTART:20260105T100000Z\r\nDTEND:20260105T101500Z\r\n"+
+			"RRULE:FREQ=DAILY\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:standup\r\nRECURRENCE-ID:20260106T100000Z\r\nSTATUS:CANCELLED\r\n"+
+			"DTSTART:20260106T100000Z\r\nDTEND:20260106T101500Z\r\nEND:VEVENT\r\n",
+	)
+
+	events := c.Between(monday, monday.AddDate(0, 0, 3))
+
+	expected := []string{"Mon 05 10:00", "Wed 07 10:00"}
+	if got := starts(events); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestParse_GivenDurationBeforeStart_WhenQueried_ThenEndFollowsStart(t *testing.T) {
+	c := parse(t, "BEGIN:VEVENT\r\nUID:1\r\nDURATION:PT45M\r\nDTSTART:20260105T100000Z\r\nEND:VEVENT\r\n")
+
+	events := c.Between(monday, monday.AddDate(0, 0, 1))
+
+	if len(events) != 1 || !events[0].End.Equal(monday.Add(10*time.Hour+45*time.Minute)) {
+		t.Fatalf("expected 10:00-10:45, got %+v", events)
+	}
+}
+
+func TestParse_GivenDailyRuleWithCountAndUntil_WhenQueried_ThenStopsInTime(t *testing.T) {
+	c := parse(t,
+		"BEGIN:VEVENT\r\nUID:a\r\nDTSTART:20260105T080000Z\r\nDTEND:20260105T081500Z\r\nRRULE:FREQ=DAILY;COUNT=2\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:b\r\nDTSTART:20260105T120000Z\r\nDTEND:20260105T121500Z\r\nRRULE:FREQ=DAILY;INTERVAL=2;UNTIL=20260109\r\nEND:VEVENT\r\n",
+	)
+
+	events := c.Between(monday, monday.AddDate(0, 0, 7))
+
+	expected := []string{"Mon 05 08:00", "Mon 05 12:00", "Tue 06 08:00", "Wed 07 12:00", "Fri 09 12:00"}
+	if got := starts(events); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestParse_GivenLongRunningRule_WhenQueriedYearsLater_ThenFindsOccurrence(t *testing.T) {
+	c := parse(t, "BEGIN:VEVENT\r\nUID:a\r\nDTSTART:20200106T093000Z\r\nDTEND:20200106T094500Z\r\nRRULE:FREQ=WEEKLY\r\nEND:VEVENT\r\n")
+
+	events := c.Between(monday, monday.AddDate(0, 0, 1))
+
+	if got := starts(events); len(got) != 1 || got[0] != "Mon 05 09:30" {
+		t.Fatalf("expected this Monday's occurrence, got %v", got)
+	}
+}
+
+func TestParse_GivenFreeCancelledAndAllDayEvents_WhenQueried_ThenLeftOut(t *testing.T) {
+	c := parse(t,
+		"BEGIN:VEVENT\r\nUID:a\r\nDTSTART:20260105T100000Z\r\nDTEND:20260105T110000Z\r\nTRANSP:TRANSPARENT\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:b\r\nDTSTART:20260105T100000Z\r\nDTEND:20260105T110000Z\r\nSTATUS:CANCELLED\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:c\r\nDTSTART;VALUE=DATE:20260105\r\nDTEND;VALUE=DATE:20260106\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:d\r\nDTSTART:20260105T100000Z\r\nRRULE:FREQ=MONTHLY;BYDAY=1MO\r\nDURATION:PT1H\r\nEND:VEVENT\r\n",
+	)
+
+	events := c.Between(monday, monday.AddDate(0, 3, 0))
+
+	if len(events) != 1 || events[0].UID != "d" {
+		t.Fatalf("expected only the first occurrence of the unsupported rule, got %+v", events)
+	}
+}
+
+func TestParse_GivenMalformedDate_WhenParsed_ThenFails(t *testing.T) {
+	_, err := calendar.Parse(strings.NewReader("BEGIN:VEVENT\r\nDTSTART:tomorrow\r\nEND:VEVENT\r\n"))
+
+	if err == nil || !strings.Contains(err.Error(), "line 2: DTSTART") {
+		t.Fatalf("expected an error for line 2, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package gopomodoro
+
+import "time"
+
+// Checkpoint is the persistent state of a Cycle, taken with Checkpoint and
+// applied with Restore, typically by a later process.
+type Checkpoint struct {
+	State         CycleState
+	PomodoroCount int
+	// Step is the index of the current phase in the schedule's steps.
+	Step          int
+	Paused        bool
+	Waiting       bool
+	Interruptions Interruptions
+	Extension     time.Duration
+	Task          Task
+
+	// Profile is the name of the active profile. Restore leaves the
+	// configuration alone; apply the profile through SetProfile first.
+	Profile string
+
+	// StopPending is set when the cycle returns to Idle once the current
+	// phase ends; see StopAfterPhase.
+	StopPending bool
+
+	// RestUntil is when the rest owed under the BreakPolicy is over; it is
+	// kept while Idle too.
+	RestUntil time.Time
+
+	// PhaseStarted is when the current phase started; PausedFor and PausedAt
+	// record the time it spent paused.
+	PhaseStarted time.Time
+	PausedFor    time.Duration
+	PausedAt     time.Time
+
+	// Deadline is when the running phase ends. It is zero when Idle, paused
+	// or waiting; Remaining holds the frozen time left instead.
+	Deadline  time.Time
+	Remaining time.Duration
+
+	SavedAt time.Time
+}
+
+// Checkpoint returns the state needed to continue the cycle elsewhere.
+func (c *Cycle) Checkpoint() Checkpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cp := Checkpoint{
+		State:         c.State,
+		PomodoroCount: c.pomodoroCount,
+		Step:          c.step,
+		Paused:        c.paused,
+		Waiting:       c.waiting,
+		Interruptions: c.interruptions,
+		Extension:     c.extension,
+		Task:          c.task.clone(),
+		Profile:       c.profile,
+		StopPending:   c.stopPending,
+		RestUntil:     c.restUntil,
+		PhaseStarted:  c.started,
+		PausedFor:     c.pausedFor,
+		PausedAt:      c.pausedAt,
+		Deadline:      c.deadline,
+		SavedAt:       c.now(),
+	}
+	if c.deadline.IsZero() {
+		cp.Remaining = c.TimeLeft
+	}
+	return cp
+}
+
+// Restore replaces the state of an Idle cycle with cp. A running phase
+// continues towards its original deadline; phases that would have ended in
+// the meantime are completed in order, with events timestamped at the time
+// they would have ended and without sounding the Notifier. Restore emits a
+// Restored event once the cycle has caught up. An Idle checkpoint only
+// restores the task and the rest owed. It has no effect unless the cycle is
+// Idle.
+func (c *Cycle) Restore(cp Checkpoint) {
+	c.mu.Lock()
+	c.restore(cp)
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) restore(cp Checkpoint) {
+	if c.State != Idle {
+		return
+	}
+	c.restUntil = cp.RestUntil
+	if cp.State == Idle {
+		c.setTask(cp.Task)
+		if c.restLeft(c.now()) > 0 {
+			c.notifyStateChanged()
+			c.startTicker()
+		}
+		return
+	}
+	c.State = cp.State
+	c.pomodoroCount = cp.PomodoroCount
+	c.step = c.locateStep(cp.Step)
+	c.paused = cp.Paused
+	c.waiting = cp.Waiting
+	c.interruptions = cp.Interruptions
+	c.extension = cp.Extension
+	c.task = cp.Task.clone()
+	c.stopPending = cp.StopPending
+	c.started = cp.PhaseStarted
+	c.pausedFor = cp.PausedFor
+	c.pausedAt = cp.PausedAt
+	c.deadline = cp.Deadline
+	c.TimeLeft = cp.Remaining
+
+	if !c.deadline.IsZero() {
+		c.catchUp()
+	}
+
+	if c.State != Idle {
+		c.emit(Restored)
+		c.notifyStateChanged()
+		if !c.paused && !c.waiting {
+			c.startTicker()
+		}
+	}
+}
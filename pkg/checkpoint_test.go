@@ -0,0 +1,139 @@
+package gopomodoro_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestCheckpoint_GivenRunningPomodoro_WhenRestoredBeforeDeadline_ThenContinuesWhereItLeftOff(t *testing.T) {
+	previous, clock, _ := pomotest.NewCycle(nil)
+	previous.Start()
+	clock.Advance(10 * time.Minute)
+	cp := previous.Checkpoint()
+
+	clock.Advance(5 * time.Minute)
+	ticker := &pomotest.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker, Clock: clock}
+	c.Restore(cp)
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.Pomodoro || snapshot.Remaining != 10*time.Minute {
+		t.Fatalf("expected Pomodoro with 10m remaining, got %+v", snapshot)
+	}
+	if !ticker.Started() {
+		t.Fatal("expected ticker to be running after restore")
+	}
+}
+
+func TestCheckpoint_GivenPausedPomodoro_WhenRestored_ThenStaysPausedWithFrozenTime(t *testing.T) {
+	previous, clock, _ := pomotest.NewCycle(nil)
+	previous.Start()
+	previous.Interrupt(gopomodoro.ExternalInterruption)
+	clock.Advance(10 * time.Minute)
+	previous.Pause()
+	cp := previous.Checkpoint()
+
+	clock.Advance(3 * time.Hour)
+	ticker := &pomotest.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker, Clock: clock}
+	c.Restore(cp)
+
+	expected := gopomodoro.Snapshot{
+		State:         gopomodoro.Pomodoro,
+		Remaining:     15 * time.Minute,
+		Paused:        true,
+		Interruptions: gopomodoro.Interruptions{External: 1},
+	}
+	if snapshot := c.Snapshot(); !reflect.DeepEqual(snapshot, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, snapshot)
+	}
+	if ticker.Started() {
+		t.Fatal("expected ticker to stay stopped for a paused cycle")
+	}
+}
+
+func TestCheckpoint_GivenPomodoroEndedWhileDown_WhenRestored_ThenBreakContinuesFromOriginalDeadline(t *testing.T) {
+	previous, clock, _ := pomotest.NewCycle(nil)
+	previous.Start()
+	cp := previous.Checkpoint()
+
+	clock.Advance(27 * time.Minute)
+	subscriber := &pomotest.MockSubscriber{}
+	notifier := &pomotest.MockNotifier{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock, Notifier: notifier}
+	c.Subscribe(subscriber)
+	c.Restore(cp)
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.ShortBreak || snapshot.Remaining != 3*time.Minute || snapshot.PomodoroCount != 1 {
+		t.Fatalf("expected ShortBreak with 3m remaining after 1 pomodoro, got %+v", snapshot)
+	}
+	completed, ok := subscriber.First(gopomodoro.PhaseCompleted)
+	if !ok || !completed.Time.Equal(pomotest.StartTime.Add(25*time.Minute)) {
+		t.Fatalf("expected pomodoro to complete at its deadline, got %+v", completed)
+	}
+	if _, ok := subscriber.Last(gopomodoro.Restored); !ok {
+		t.Fatal("expected a Restored event")
+	}
+	if notifier.NotifyCallCount != 0 {
+		t.Fatalf("expected no sound for replayed phases, got %d", notifier.NotifyCallCount)
+	}
+}
+
+func TestCheckpoint_GivenWholeSetEndedWhileDown_WhenRestored_ThenIdle(t *testing.T) {
+	previous, clock, _ := pomotest.NewCycle(nil)
+	previous.Start()
+	cp := previous.Checkpoint()
+
+	clock.Advance(24 * time.Hour)
+	subscriber := &pomotest.MockSubscriber{}
+	ticker := &pomotest.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker, Clock: clock}
+	c.Subscribe(subscriber)
+	c.Restore(cp)
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected Idle, got %v", c.Snapshot().State)
+	}
+	completed, ok := subscriber.Last(gopomodoro.SetCompleted)
+	expectedEnd := pomotest.StartTime.Add(4*25*time.Minute + 3*5*time.Minute + 15*time.Minute)
+	if !ok || !completed.Time.Equal(expectedEnd) {
+		t.Fatalf("expected set to complete at %v, got %+v", expectedEnd, completed)
+	}
+	if ticker.Started() {
+		t.Fatal("expected ticker to stay stopped")
+	}
+}
+
+func TestCheckpoint_GivenRunningCycle_WhenRestored_ThenNothingChanges(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+
+	c.Restore(gopomodoro.Checkpoint{State: gopomodoro.LongBreak, Remaining: time.Minute, Paused: true})
+
+	if !c.Is(gopomodoro.Pomodoro) || c.IsPaused() {
+		t.Fatalf("expected running Pomodoro to be kept, got %+v", c.Snapshot())
+	}
+}
+
+func TestCheckpoint_GivenStopPendingAndPhaseEndedWhileDown_WhenRestored_ThenIdle(t *testing.T) {
+	previous, clock, _ := pomotest.NewCycle(nil)
+	previous.Start()
+	previous.StopAfterPhase()
+	cp := previous.Checkpoint()
+
+	clock.Advance(40 * time.Minute)
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock}
+	c.Subscribe(subscriber)
+	c.Restore(cp)
+
+	halted, ok := subscriber.Last(gopomodoro.Halted)
+	if !c.Is(gopomodoro.Idle) || !ok || !halted.Time.Equal(pomotest.StartTime.Add(25*time.Minute)) {
+		t.Fatalf("expected to halt at the pomodoro's deadline, got %+v, %+v", c.Snapshot(), halted)
+	}
+}
@@ -1,18 +1,62 @@
 package gopomodoro
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
 
 // CycleState represents the state of the pomodoro cycle.
-// When used as duration, the value represents minutes.
+// Phase lengths are configured separately through Schedule or Durations.
 type CycleState int
 
 const (
-	Idle       CycleState = 0
-	ShortBreak CycleState = 5
-	LongBreak  CycleState = 15
-	Pomodoro   CycleState = 25
+	Idle CycleState = iota
+	Pomodoro
+	ShortBreak
+	LongBreak
 )
 
+func (s CycleState) String() string {
+	switch s {
+	case Idle:
+		return "Idle"
+	case Pomodoro:
+		return "Pomodoro"
+	case ShortBreak:
+		return "ShortBreak"
+	case LongBreak:
+		return "LongBreak"
+	default:
+		return "CycleState(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// ParseCycleState returns the state named by s, as produced by String.
+func ParseCycleState(s string) (CycleState, error) {
+	for _, state := range []CycleState{Idle, Pomodoro, ShortBreak, LongBreak} {
+		if state.String() == s {
+			return state, nil
+		}
+	}
+	return Idle, fmt.Errorf("unknown cycle state %q", s)
+}
+
+// MarshalText encodes the state by name, so persisted files stay readable.
+func (s CycleState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *CycleState) UnmarshalText(text []byte) error {
+	state, err := ParseCycleState(string(text))
+	if err != nil {
+		return err
+	}
+	*s = state
+	return nil
+}
+
 // Ticker provides time ticks for the pomodoro countdown.
 type Ticker interface {
 	Start()
@@ -20,111 +64,696 @@ type Ticker interface {
 	OnTick() <-chan struct{}
 }
 
+// Clock provides the current wall-clock time.
+type Clock interface {
+	Now() time.Time
+}
+
 // CycleObserver receives notifications of cycle state changes.
 type CycleObserver interface {
 	OnStateChanged(state CycleState)
 }
 
+// Cycle is the pomodoro state machine. It is safe for concurrent use once
+// constructed: the exported fields configure the cycle and must not be
+// touched afterwards; read the current state through Snapshot. The one
+// exception is SetProfile, which replaces Durations, Schedule,
+// ConfirmBreaks and ConfirmPomodoros under the cycle's lock, so read them
+// only before the cycle is shared.
 type Cycle struct {
-	State    CycleState
+	State CycleState
+	// TimeLeft is the remaining time of the current phase as of the last
+	// tick. Use Remaining for a value that is accurate to the second.
 	TimeLeft time.Duration
 	Ticker   Ticker
 	Observer CycleObserver
 	Notifier Notifier
 
+	// Clock is optional; the system clock is used when nil.
+	Clock Clock
+
+	// Durations configures phase lengths and the long break interval.
+	// The zero value uses DefaultDurations.
+	Durations Durations
+
+	// ConfirmBreaks and ConfirmPomodoros disable auto-starting the next
+	// break or pomodoro. When set, the cycle waits after the previous phase
+	// completes until Start is called.
+	ConfirmBreaks    bool
+	ConfirmPomodoros bool
+
+	// Schedule lists the phases of a set. The zero value runs
+	// DefaultSchedule for Durations.
+	Schedule Schedule
+
+	// ExtensionLimits caps how far Extend can push out each phase.
+	// The zero value uses DefaultExtensionLimits.
+	ExtensionLimits ExtensionLimits
+
+	// Goals sets daily and weekly pomodoro goals. The zero value disables them.
+	Goals Goals
+
+	// Strict enforces that a pomodoro cannot be split: it cannot be
+	// paused, and Stop only asks for confirmation through Abandon within
+	// AbandonTimeout, which uses DefaultAbandonTimeout when zero.
+	Strict         bool
+	AbandonTimeout time.Duration
+
+	// BreakPolicy makes breaks mandatory; see BreakPolicy. The zero value
+	// leaves them optional.
+	BreakPolicy BreakPolicy
+
+	// Gate is optional; when set, a pomodoro only starts if it admits it.
+	// A pomodoro due to start automatically after a break waits for Start
+	// instead when refused.
+	Gate StartGate
+
+	// mu guards all fields below as well as State and TimeLeft.
+	mu sync.Mutex
+
 	// pomodoroCount tracks completed pomodoros to determine break type.
 	// Increments when a pomodoro completes, persists across short breaks,
 	// resets to 0 when Stop() is called or after a long break completes.
 	pomodoroCount int
+
+	// step is the index of the current phase in steps, the schedule with
+	// its repetitions spelled out. steps is built on first use by plan.
+	step  int
+	steps []Step
+
+	// paused is a sub-state of a running phase. While paused, State and
+	// TimeLeft are frozen and the Ticker is stopped.
+	paused bool
+
+	// waiting is set when the previous phase completed and State holds the
+	// next phase, which has not started yet. TimeLeft is its full duration.
+	waiting bool
+
+	// interruptions recorded against the current pomodoro. Cleared when the
+	// pomodoro completes or the cycle is reset.
+	interruptions Interruptions
+
+	// task labels the pomodoros; it survives transitions and reset.
+	task Task
+
+	// progress counts completed pomodoros towards Goals.
+	progress progress
+
+	// profile is the name of the active profile and silent its setting for
+	// the Notifier. pendingProfile is applied when the running phase ends.
+	profile        string
+	silent         bool
+	pendingProfile *Profile
+
+	// notice explains why the Gate refused, shortened or warned about the
+	// last pomodoro; it is cleared when the phase ends.
+	notice string
+
+	// idle is the time the pomodoro ran while the user was idle before
+	// PauseIdle paused it; DiscardIdle gives it back. It is cleared when
+	// the phase ends.
+	idle time.Duration
+
+	// restUntil is when the rest owed under BreakPolicy for the last
+	// pomodoro is over. It survives Stop.
+	restUntil time.Time
+
+	// abandonBy is the time until which Abandon confirms a Stop in Strict
+	// mode; it is zero when no Stop awaits confirmation.
+	abandonBy time.Time
+
+	// stopPending is set through StopAfterPhase: the cycle returns to Idle
+	// when the running phase ends instead of moving on.
+	stopPending bool
+
+	// extension is the total time added to the current phase through Extend.
+	extension time.Duration
+
+	// started is when the current phase started. pausedFor accumulates the
+	// time it spent paused; pausedAt is when the current pause began.
+	started   time.Time
+	pausedFor time.Duration
+	pausedAt  time.Time
+
+	// deadline is the wall-clock time at which the running phase ends.
+	// It is zero while Idle, paused or waiting.
+	deadline time.Time
+
+	// replayAt overrides the clock while Restore replays phases that ended
+	// while no process was running.
+	replayAt time.Time
+
+	// ticking is set once the tick-consuming goroutine has been spawned,
+	// so resuming does not start a second consumer.
+	ticking bool
+
+	// subscriptions receive every Event; see Subscribe.
+	subscriptions      []subscription
+	nextSubscriptionID int
+
+	// outbox holds observer, subscriber and notifier callbacks queued while mu was held.
+	// They are run in order by deliver, outside the lock.
+	outbox     []func()
+	delivering bool
+}
+
+// Snapshot is an immutable view of a Cycle at a point in time.
+type Snapshot struct {
+	State         CycleState
+	Remaining     time.Duration
+	Paused        bool
+	Waiting       bool
+	PomodoroCount int
+	Interruptions Interruptions
+	Extension     time.Duration
+	Task          Task
+	Progress      Progress
+
+	// Profile is the name of the active profile. PendingProfile names the
+	// profile that takes over from the next phase, if any.
+	Profile        string
+	PendingProfile string
+
+	// StopPending is set when the cycle returns to Idle once the running
+	// phase ends; see StopAfterPhase.
+	StopPending bool
+
+	// Notice explains why the Gate refused, shortened or warned about the
+	// last pomodoro.
+	Notice string
+
+	// RestLeft is the rest owed under BreakPolicy that must still pass
+	// before the next pomodoro may start.
+	RestLeft time.Duration
+
+	// AbandonPending is set in Strict mode while Stop awaits confirmation
+	// through Abandon.
+	AbandonPending bool
+
+	// IdleTime is the idle time DiscardIdle would give back to the
+	// pomodoro paused by PauseIdle.
+	IdleTime time.Duration
+}
+
+// Snapshot returns a consistent view of the cycle.
+func (c *Cycle) Snapshot() Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := Snapshot{
+		State:          c.State,
+		Remaining:      c.remaining(),
+		Paused:         c.paused,
+		Waiting:        c.waiting,
+		PomodoroCount:  c.pomodoroCount,
+		Interruptions:  c.interruptions,
+		Extension:      c.extension,
+		Task:           c.task.clone(),
+		Progress:       c.snapshotProgress(),
+		Profile:        c.profile,
+		StopPending:    c.stopPending,
+		Notice:         c.notice,
+		IdleTime:       c.idle,
+		AbandonPending: c.abandonPending(),
+		RestLeft:       c.restLeft(c.now()),
+	}
+	if c.pendingProfile != nil {
+		s.PendingProfile = c.pendingProfile.Name
+	}
+	return s
 }
 
 func (c *Cycle) Is(s CycleState) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.State == s
 }
 
+// PhaseDuration returns the length of the current phase if it is of kind s,
+// or else of the next phase of that kind in the schedule.
+func (c *Cycle) PhaseDuration(s CycleState) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.phaseDuration(s)
+}
+
+// IsPaused reports whether a running phase is currently paused.
+func (c *Cycle) IsPaused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+func (c *Cycle) now() time.Time {
+	if !c.replayAt.IsZero() {
+		return c.replayAt
+	}
+	if c.Clock != nil {
+		return c.Clock.Now()
+	}
+	return time.Now()
+}
+
+// notifyStateChanged queues an observer callback. Must be called with mu held.
 func (c *Cycle) notifyStateChanged() {
 	if c.Observer != nil {
-		c.Observer.OnStateChanged(c.State)
+		observer, state := c.Observer, c.State
+		c.outbox = append(c.outbox, func() { observer.OnStateChanged(state) })
 	}
 }
 
+// notify queues a notifier callback. Must be called with mu held.
 func (c *Cycle) notify() {
-	if c.Notifier != nil {
-		c.Notifier.Notify()
+	if c.Notifier != nil && c.replayAt.IsZero() && !c.silent {
+		notifier := c.Notifier
+		c.outbox = append(c.outbox, notifier.Notify)
 	}
 }
 
+// deliver runs queued callbacks in the order they were queued, without
+// holding mu, so callbacks may call back into the cycle. If another
+// goroutine (or an enclosing callback) is already delivering, it picks up
+// the new callbacks instead.
+func (c *Cycle) deliver() {
+	c.mu.Lock()
+	if c.delivering {
+		c.mu.Unlock()
+		return
+	}
+	c.delivering = true
+	for len(c.outbox) > 0 {
+		next := c.outbox[0]
+		c.outbox = c.outbox[1:]
+		c.mu.Unlock()
+		next()
+		c.mu.Lock()
+	}
+	c.delivering = false
+	c.mu.Unlock()
+}
+
+// Start begins a new pomodoro from Idle, begins the next phase when waiting
+// for confirmation, or resumes a paused phase.
 func (c *Cycle) Start() {
 	if c.Ticker == nil {
 		panic("Cycle.Start called without Ticker")
 	}
+	c.mu.Lock()
+	c.start()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) start() {
+	if c.paused {
+		c.resume()
+		return
+	}
+	if c.waiting {
+		length := c.TimeLeft
+		if c.State == Pomodoro {
+			var ok bool
+			if length, ok = c.admitPomodoro(c.now()); !ok {
+				return
+			}
+		}
+		c.waiting = false
+		c.started = c.now()
+		c.deadline = c.started.Add(length)
+		c.emit(PhaseStarted)
+		c.notifyStateChanged()
+		c.startTicker()
+		return
+	}
 	if c.State == Idle {
+		c.step = 0
+		length, ok := c.admitPomodoro(c.now())
+		if !ok {
+			return
+		}
 		c.State = Pomodoro
-		c.TimeLeft = time.Duration(Pomodoro) * time.Minute
+		c.TimeLeft = length
+		c.started = c.now()
+		c.deadline = c.started.Add(c.TimeLeft)
+		c.emit(PhaseStarted)
 		c.notifyStateChanged()
-		c.Ticker.Start()
-		go func() {
-			for range c.Ticker.OnTick() {
-				c.AdvanceMinute()
-			}
-		}()
+		c.startTicker()
 	}
 }
 
+// Stop abandons the running phase and returns to Idle. A started pomodoro
+// is voided with DefaultVoidReason. In Strict mode a started pomodoro is
+// only abandoned once Abandon confirms the Stop.
 func (c *Cycle) Stop() {
+	c.mu.Lock()
+	if c.Strict && c.State == Pomodoro && !c.waiting {
+		c.requestAbandon()
+	} else {
+		c.stop(DefaultVoidReason)
+	}
+	c.mu.Unlock()
+	c.deliver()
+}
+
+// stop abandons the running phase, if any, and resets the cycle. A started
+// pomodoro is voided for reason.
+func (c *Cycle) stop(reason string) {
+	if c.State != Idle && !c.waiting {
+		stopped := c.event(Stopped)
+		stopped.Elapsed = c.elapsed()
+		if c.State == Pomodoro {
+			stopped.Voided = true
+			stopped.Reason = reason
+		}
+		c.publish(stopped)
+	}
+	c.reset()
+}
+
+// StopAfterPhase lets the running phase run to completion, notifying as
+// usual, and then returns the cycle to Idle instead of moving on to the next
+// phase. It has no effect when Idle or waiting, where Stop is immediate
+// anyway.
+func (c *Cycle) StopAfterPhase() {
+	c.mu.Lock()
+	c.stopAfterPhase()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) stopAfterPhase() {
+	if c.State == Idle || c.waiting || c.stopPending {
+		return
+	}
+	c.stopPending = true
+	c.emit(StopScheduled)
+	c.notifyStateChanged()
+}
+
+// CancelStopAfterPhase withdraws a stop requested through StopAfterPhase, so
+// the cycle moves on to the next phase as usual.
+func (c *Cycle) CancelStopAfterPhase() {
+	c.mu.Lock()
+	c.cancelStopAfterPhase()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) cancelStopAfterPhase() {
+	if !c.stopPending {
+		return
+	}
+	c.stopPending = false
+	c.emit(StopCancelled)
+	c.notifyStateChanged()
+}
+
+// elapsed returns the time the running phase has run so far, excluding
+// pauses. Must be called with mu held.
+func (c *Cycle) elapsed() time.Duration {
+	if c.started.IsZero() {
+		return c.phaseDuration(c.State) + c.extension - c.remaining()
+	}
+	elapsed := c.now().Sub(c.started) - c.pausedFor
+	if c.paused {
+		elapsed -= c.now().Sub(c.pausedAt)
+	}
+	return elapsed
+}
+
+// reset returns the cycle to Idle and clears the set.
+func (c *Cycle) reset() {
 	c.State = Idle
 	c.TimeLeft = 0
 	c.pomodoroCount = 0
+	c.step = 0
+	c.interruptions = Interruptions{}
+	c.extension = 0
+	c.started = time.Time{}
+	c.pausedFor = 0
+	c.pausedAt = time.Time{}
+	c.paused = false
+	c.waiting = false
+	c.deadline = time.Time{}
+	c.stopPending = false
+	c.notice = ""
+	c.idle = 0
+	c.abandonBy = time.Time{}
 	c.notifyStateChanged()
+	c.stopTicker()
+	c.applyPendingProfile()
+}
+
+// Pause freezes the running phase. It has no effect when Idle, waiting or
+// already paused, and on a pomodoro in Strict mode.
+func (c *Cycle) Pause() {
+	c.mu.Lock()
+	c.pause()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) pause() {
+	if c.State == Idle || c.paused || c.waiting || c.Strict && c.State == Pomodoro {
+		return
+	}
+	c.TimeLeft = c.remaining()
+	c.deadline = time.Time{}
+	c.paused = true
+	c.pausedAt = c.now()
 	c.Ticker.Stop()
+	c.emit(Paused)
+	c.notifyStateChanged()
+}
+
+// Resume continues a paused phase with the time that was left when it was paused.
+func (c *Cycle) Resume() {
+	c.mu.Lock()
+	c.resume()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) resume() {
+	if !c.paused {
+		return
+	}
+	c.paused = false
+	c.pausedFor += c.now().Sub(c.pausedAt)
+	c.pausedAt = time.Time{}
+	c.deadline = c.now().Add(c.TimeLeft)
+	c.emit(Resumed)
+	c.notifyStateChanged()
+	c.startTicker()
+}
+
+func (c *Cycle) startTicker() {
+	c.Ticker.Start()
+	if c.ticking {
+		return
+	}
+	c.ticking = true
+	ticks := c.Ticker.OnTick()
+	go func() {
+		for range ticks {
+			c.Tick()
+		}
+	}()
 }
 
+// Remaining returns the time left in the current phase, rounded up to whole
+// seconds. While running it is derived from the phase deadline, so it does not
+// depend on how often the Ticker fires.
 func (c *Cycle) Remaining() time.Duration {
-	return c.TimeLeft
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remaining()
+}
+
+func (c *Cycle) remaining() time.Duration {
+	if c.deadline.IsZero() {
+		return c.TimeLeft
+	}
+	left := c.deadline.Sub(c.now())
+	if left <= 0 {
+		return 0
+	}
+	return (left + time.Second - 1).Truncate(time.Second)
 }
 
-// AdvanceMinute decrements the timer by one minute and may transition state.
+// Tick synchronises the countdown with the clock and may transition state.
+// It has no effect while the cycle is paused or waiting.
+func (c *Cycle) Tick() {
+	c.mu.Lock()
+	c.advance(0)
+	c.mu.Unlock()
+	c.deliver()
+}
+
+// AdvanceMinute moves the phase deadline one minute closer, as if a minute
+// had passed, and may transition state. It has no effect while paused or
+// waiting.
 func (c *Cycle) AdvanceMinute() {
-	switch c.State {
-	case Pomodoro:
-		c.advancePomodoro()
-	case ShortBreak:
-		c.advanceShortBreak()
-	case LongBreak:
-		c.advanceLongBreak()
+	c.mu.Lock()
+	c.advance(time.Minute)
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) advance(d time.Duration) {
+	if c.paused || c.waiting {
+		if c.countDownRest() {
+			c.notifyStateChanged()
+		}
+		return
+	}
+	if c.State == Idle {
+		c.countDownRest()
+	}
+	if c.State != Idle {
+		if c.deadline.IsZero() {
+			c.deadline = c.now().Add(c.TimeLeft)
+		}
+		c.deadline = c.deadline.Add(-d)
+		c.TimeLeft = c.remaining()
+		c.emit(Ticked)
+	}
+	if c.State != Idle && c.TimeLeft <= 0 {
+		c.completePhase(false)
+		if c.replayAt.IsZero() {
+			c.catchUp()
+		}
 	}
 	c.notifyStateChanged()
 }
 
-func (c *Cycle) advancePomodoro() {
-	c.TimeLeft -= time.Minute
-	if c.TimeLeft <= 0 {
+// catchUp completes the phases whose deadlines passed while no ticks came,
+// because no process was running or the machine was suspended, so the cycle
+// lands in the phase the clock is in. As the phases were not witnessed,
+// their events are timestamped at the time they would have ended and the
+// Notifier stays silent. Must be called with mu held.
+func (c *Cycle) catchUp() {
+	now := c.now()
+	for c.State != Idle && !c.paused && !c.waiting && !c.deadline.IsZero() && !c.deadline.After(now) {
+		c.replayAt = c.deadline
+		c.advance(0)
+	}
+	c.replayAt = time.Time{}
+	c.TimeLeft = c.remaining()
+}
+
+// Skip ends the current phase immediately and moves on to the next one,
+// exactly as if its timer had run out: a skipped pomodoro counts towards the
+// long break and the Notifier fires. A paused phase is skipped too and the
+// next phase starts running; when waiting, the pending phase is passed over,
+// and a pending pomodoro does not count as it never ran. It has no effect when Idle, and on a started pomodoro in Strict mode,
+// which cannot be ended early.
+func (c *Cycle) Skip() {
+	c.mu.Lock()
+	c.skip()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) skip() {
+	if c.State == Idle || c.Strict && c.State == Pomodoro && !c.waiting {
+		return
+	}
+	wasStopped := c.paused || c.waiting
+	c.completePhase(true)
+	if wasStopped && c.State != Idle && !c.waiting {
+		c.startTicker()
+	}
+	c.notifyStateChanged()
+}
+
+// completePhase ends the current phase and transitions to the next one.
+// A skipped phase is ended early, so the next phase starts now rather than
+// at the original deadline. A phase skipped while waiting for Start never
+// ran, so it is passed over without PhaseCompleted and a pomodoro does not
+// count.
+func (c *Cycle) completePhase(skipped bool) {
+	ran := !c.waiting
+	if ran {
+		completed := c.event(PhaseCompleted)
+		completed.Skipped = skipped
+		c.publish(completed)
+	}
+	if skipped {
+		c.paused = false
+		c.pausedAt = time.Time{}
+		c.waiting = false
+		c.deadline = c.now()
+	}
+	c.notice = ""
+	c.idle = 0
+	c.abandonBy = time.Time{}
+	c.applyPendingProfile()
+	c.step = c.locateStep(c.step)
+	if c.State == Pomodoro && ran {
+		c.oweRest(c.deadline)
 		c.pomodoroCount++
-		if c.pomodoroCount >= 4 {
-			c.State = LongBreak
-			c.TimeLeft = time.Duration(LongBreak) * time.Minute
-		} else {
-			c.State = ShortBreak
-			c.TimeLeft = time.Duration(ShortBreak) * time.Minute
+		if !skipped {
+			c.countPomodoro()
+			c.countGoals()
 		}
-		c.notify()
+		c.interruptions = Interruptions{}
 	}
+	c.advanceStep()
 }
 
-func (c *Cycle) advanceShortBreak() {
-	c.TimeLeft -= time.Minute
-	if c.TimeLeft <= 0 {
-		c.State = Pomodoro
-		c.TimeLeft = time.Duration(Pomodoro) * time.Minute
+// advanceStep enters the next step of the schedule, or completes the set
+// and returns to Idle after the last one. A pending stop returns to Idle
+// without entering the next step.
+func (c *Cycle) advanceStep() {
+	plan := c.plan()
+	c.step++
+	if c.step >= len(plan) {
+		c.notify()
+		c.emit(SetCompleted)
+		c.reset()
+		return
+	}
+	if c.stopPending {
 		c.notify()
+		c.emit(Halted)
+		c.reset()
+		return
 	}
+	c.enter(plan[c.step].Phase)
+	c.notify()
 }
 
-func (c *Cycle) advanceLongBreak() {
-	c.TimeLeft -= time.Minute
-	if c.TimeLeft <= 0 {
-		c.notify()
-		c.Stop()
+// enter switches to the next phase. Its deadline follows on from the end of
+// the previous phase, so late ticks do not make the cycle drift. If the phase
+// needs confirmation or the Gate refuses a pomodoro, the cycle waits for
+// Start instead.
+func (c *Cycle) enter(s CycleState) {
+	c.State = s
+	c.extension = 0
+	c.pausedFor = 0
+	c.started = c.deadline
+	length, admitted := c.phaseDuration(s), true
+	if s == Pomodoro && !c.confirms(s) {
+		length, admitted = c.admitPomodoro(c.started)
+	}
+	if c.confirms(s) || !admitted {
+		c.waiting = true
+		c.started = time.Time{}
+		c.deadline = time.Time{}
+		c.TimeLeft = c.phaseDuration(s)
+		c.stopTicker()
+		c.emit(Waiting)
+		return
 	}
+	c.deadline = c.deadline.Add(length)
+	c.TimeLeft = c.remaining()
+	c.emit(PhaseStarted)
+}
 
+// confirms reports whether phase s waits for Start instead of auto-starting.
+func (c *Cycle) confirms(s CycleState) bool {
+	if s == Pomodoro {
+		return c.ConfirmPomodoros
+	}
+	return c.ConfirmBreaks
 }
@@ -1,6 +1,8 @@
 package gopomodoro_test
 
 import (
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -45,7 +47,7 @@ func TestStartedPomodoroHas25MinutesRemaining(t *testing.T) {
 
 	c.Start()
 
-	expected := time.Duration(gopomodoro.Pomodoro) * time.Minute
+	expected := 25 * time.Minute
 	if c.Remaining() != expected {
 		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
 	}
@@ -58,7 +60,7 @@ func TestTickDecrementsRemainingMinutes(t *testing.T) {
 
 	c.AdvanceMinute()
 
-	expected := time.Duration(gopomodoro.Pomodoro)*time.Minute - time.Minute
+	expected := 24 * time.Minute
 	if c.Remaining() != expected {
 		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
 	}
@@ -199,6 +201,7 @@ func TestTickerFireTriggersTickAndNotifiesObserver(t *testing.T) {
 
 	c.Start()
 	ticker.Fire()
+	observer.WaitForStateChanges(2)
 
 	// Should have 2 state changes: one from Start, one from Tick
 	if len(observer.StateChanges) != 2 {
@@ -313,3 +316,706 @@ func TestCycle_GivenPomodoroRunning_WhenStopClicked_ThenCounterResets(t *testing
 		t.Fatalf("expected ShortBreak after first pomodoro (counter reset), got %v", c.State)
 	}
 }
+
+func TestCycle_GivenPomodoroRunning_WhenPaused_ThenTimeLeftIsFrozen(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker}
+	c.Start()
+	c.AdvanceMinute()
+
+	c.Pause()
+	c.AdvanceMinute()
+
+	if !c.IsPaused() {
+		t.Fatal("expected cycle to be paused")
+	}
+	if !c.Is(gopomodoro.Pomodoro) {
+		t.Fatalf("expected cycle to stay in Pomodoro while paused, got %v", c.State)
+	}
+	expected := 24 * time.Minute
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+	if ticker.Started() {
+		t.Fatal("expected ticker to be stopped while paused")
+	}
+}
+
+func TestCycle_GivenPausedPomodoro_WhenResumed_ThenContinuesWhereItLeftOff(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker}
+	c.Start()
+	c.AdvanceMinute()
+	c.Pause()
+
+	c.Resume()
+	c.AdvanceMinute()
+
+	if c.IsPaused() {
+		t.Fatal("expected cycle to be running after resume")
+	}
+	expected := 23 * time.Minute
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+	if !ticker.Started() {
+		t.Fatal("expected ticker to be running after resume")
+	}
+}
+
+func TestCycle_GivenPausedPomodoro_WhenStartClicked_ThenResumes(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker}
+	c.Start()
+	c.AdvanceMinute()
+	c.Pause()
+
+	c.Start()
+
+	if c.IsPaused() {
+		t.Fatal("expected Start to resume a paused cycle")
+	}
+	expected := 24 * time.Minute
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestCycle_GivenPausedShortBreak_WhenResumedAndCompleted_ThenPomodoroCountIsKept(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker}
+	for i := 0; i < 3; i++ {
+		c.Start()
+		mocks.CompleteCycle(c)
+		if i < 2 {
+			mocks.CompleteCycle(c)
+		}
+	}
+
+	c.Pause()
+	c.Resume()
+	mocks.CompleteCycle(c)
+	mocks.CompleteCycle(c)
+
+	if !c.Is(gopomodoro.LongBreak) {
+		t.Fatalf("expected LongBreak after 4th pomodoro, got %v", c.State)
+	}
+}
+
+func TestCycle_GivenIdle_WhenPaused_ThenNothingHappens(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	observer := &mocks.MockObserver{}
+	c := &gopomodoro.Cycle{Ticker: ticker, Observer: observer}
+
+	c.Pause()
+
+	if c.IsPaused() {
+		t.Fatal("expected idle cycle not to be paused")
+	}
+	if len(observer.StateChanges) != 0 {
+		t.Fatalf("expected no state changes, got %d", len(observer.StateChanges))
+	}
+}
+
+func TestCycle_GivenPausedPomodoro_WhenStopClicked_ThenReturnsToIdleUnpaused(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker}
+	c.Start()
+	c.Pause()
+
+	c.Stop()
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected cycle to be Idle, got %v", c.State)
+	}
+	if c.IsPaused() {
+		t.Fatal("expected stopped cycle not to be paused")
+	}
+}
+
+func TestPauseAndResumeNotifyObserverOfStateChange(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	observer := &mocks.MockObserver{}
+	c := &gopomodoro.Cycle{Ticker: ticker, Observer: observer}
+	c.Start()
+
+	c.Pause()
+	c.Resume()
+
+	if len(observer.StateChanges) != 3 {
+		t.Fatalf("expected 3 state changes, got %d", len(observer.StateChanges))
+	}
+}
+
+func TestCycle_GivenCustomDurations_WhenStarted_ThenPomodoroUsesConfiguredLength(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{
+		Ticker: ticker,
+		Durations: gopomodoro.Durations{
+			Pomodoro:   50 * time.Minute,
+			ShortBreak: 10 * time.Minute,
+			LongBreak:  30 * time.Minute,
+		},
+	}
+
+	c.Start()
+
+	expected := 50 * time.Minute
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestCycle_GivenCustomDurations_WhenPomodoroCompletes_ThenShortBreakUsesConfiguredLength(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{
+		Ticker: ticker,
+		Durations: gopomodoro.Durations{
+			Pomodoro:   50 * time.Minute,
+			ShortBreak: 10 * time.Minute,
+			LongBreak:  30 * time.Minute,
+		},
+	}
+	c.Start()
+
+	mocks.CompleteCycle(c)
+
+	if !c.Is(gopomodoro.ShortBreak) {
+		t.Fatalf("expected ShortBreak, got %v", c.State)
+	}
+	expected := 10 * time.Minute
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestCycle_GivenLongBreakIntervalOf2_When2ndPomodoroCompletes_ThenLongBreakUsesConfiguredLength(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{
+		Ticker: ticker,
+		Durations: gopomodoro.Durations{
+			LongBreak:         30 * time.Minute,
+			LongBreakInterval: 2,
+		},
+	}
+	c.Start()
+
+	mocks.CompleteCycle(c)
+	mocks.CompleteCycle(c)
+	mocks.CompleteCycle(c)
+
+	if !c.Is(gopomodoro.LongBreak) {
+		t.Fatalf("expected LongBreak after 2nd pomodoro, got %v", c.State)
+	}
+	expected := 30 * time.Minute
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestDurations_GivenZeroValue_WhenQueried_ThenFallsBackToDefaults(t *testing.T) {
+	d := gopomodoro.Durations{ShortBreak: 10 * time.Minute}
+
+	if d.For(gopomodoro.Pomodoro) != 25*time.Minute {
+		t.Errorf("expected default pomodoro of 25m, got %v", d.For(gopomodoro.Pomodoro))
+	}
+	if d.For(gopomodoro.ShortBreak) != 10*time.Minute {
+		t.Errorf("expected configured short break of 10m, got %v", d.For(gopomodoro.ShortBreak))
+	}
+	if d.For(gopomodoro.Idle) != 0 {
+		t.Errorf("expected idle to have no duration, got %v", d.For(gopomodoro.Idle))
+	}
+}
+
+func TestCycle_GivenRunningPomodoro_WhenClockAdvances_ThenRemainingFollowsDeadline(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock}
+	c.Start()
+
+	clock.Advance(90 * time.Second)
+
+	expected := 23*time.Minute + 30*time.Second
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestCycle_GivenRunningPomodoro_WhenPartOfASecondPassed_ThenRemainingRoundsUp(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock}
+	c.Start()
+
+	clock.Advance(24*time.Minute + 17*time.Second + 300*time.Millisecond)
+
+	expected := 43 * time.Second
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestCycle_GivenRunningPomodoro_WhenTickArrivesLate_ThenShortBreakKeepsOriginalDeadline(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock}
+	c.Start()
+
+	clock.Advance(25*time.Minute + 2*time.Second)
+	c.Tick()
+
+	if !c.Is(gopomodoro.ShortBreak) {
+		t.Fatalf("expected ShortBreak, got %v", c.State)
+	}
+	expected := 5*time.Minute - 2*time.Second
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestCycle_GivenRunningPomodoro_WhenTickBeforeDeadline_ThenStaysInPomodoro(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock}
+	c.Start()
+
+	clock.Advance(24*time.Minute + 59*time.Second)
+	c.Tick()
+
+	if !c.Is(gopomodoro.Pomodoro) {
+		t.Fatalf("expected Pomodoro, got %v", c.State)
+	}
+	if c.TimeLeft != time.Second {
+		t.Fatalf("expected 1s left, got %v", c.TimeLeft)
+	}
+}
+
+func TestCycle_GivenPausedPomodoro_WhenClockAdvances_ThenRemainingIsFrozen(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock}
+	c.Start()
+	clock.Advance(10 * time.Minute)
+	c.Pause()
+
+	clock.Advance(time.Hour)
+	c.Resume()
+	clock.Advance(time.Minute)
+
+	expected := 14 * time.Minute
+	if c.Remaining() != expected {
+		t.Fatalf("expected %v remaining, got %v", expected, c.Remaining())
+	}
+}
+
+func TestCycle_GivenRunningPomodoro_WhenSnapshotTaken_ThenReflectsCurrentState(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock}
+	c.Start()
+	mocks.CompleteCycle(c)
+	mocks.CompleteCycle(c)
+	clock.Advance(2 * time.Minute)
+	c.Pause()
+
+	snapshot := c.Snapshot()
+
+	expected := gopomodoro.Snapshot{
+		State:         gopomodoro.Pomodoro,
+		Remaining:     23 * time.Minute,
+		Paused:        true,
+		PomodoroCount: 1,
+		Progress:      gopomodoro.Progress{Today: 1, Week: 1},
+	}
+	if !reflect.DeepEqual(snapshot, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, snapshot)
+	}
+}
+
+// stoppingObserver stops the cycle from within its callback as soon as a
+// pomodoro starts, exercising re-entrant calls during delivery.
+type stoppingObserver struct {
+	cycle  *gopomodoro.Cycle
+	states []gopomodoro.CycleState
+}
+
+func (o *stoppingObserver) OnStateChanged(state gopomodoro.CycleState) {
+	o.states = append(o.states, state)
+	if state == gopomodoro.Pomodoro {
+		o.cycle.Stop()
+	}
+}
+
+func TestCycle_GivenObserverCallingBack_WhenStateChanges_ThenDeliversInOrderWithoutDeadlock(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}}
+	observer := &stoppingObserver{cycle: c}
+	c.Observer = observer
+
+	c.Start()
+
+	expected := []gopomodoro.CycleState{gopomodoro.Pomodoro, gopomodoro.Idle}
+	if len(observer.states) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, observer.states)
+	}
+	for i := range expected {
+		if observer.states[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, observer.states)
+		}
+	}
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected cycle to be Idle, got %v", c.Snapshot().State)
+	}
+}
+
+func TestCycle_GivenConcurrentCallers_WhenOperated_ThenStateStaysConsistent(t *testing.T) {
+	ticker := mocks.NewMockTicker()
+	observer := &mocks.MockObserver{}
+	notifier := &mocks.MockNotifier{}
+	c := &gopomodoro.Cycle{
+		Ticker:   ticker,
+		Observer: observer,
+		Notifier: notifier,
+		Durations: gopomodoro.Durations{
+			Pomodoro:   time.Minute,
+			ShortBreak: time.Minute,
+			LongBreak:  time.Minute,
+		},
+	}
+	c.Start()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			ticker.Fire()
+		}
+	}()
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				c.Start()
+				c.AdvanceMinute()
+				c.Pause()
+				_ = c.Snapshot()
+				c.Resume()
+				_ = c.Remaining()
+				if i%10 == 0 {
+					c.Stop()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	snapshot := c.Snapshot()
+	if snapshot.State == gopomodoro.Idle && (snapshot.Remaining != 0 || snapshot.Paused) {
+		t.Fatalf("expected idle cycle to have no time left and not be paused, got %+v", snapshot)
+	}
+}
+
+func TestCycle_GivenPomodoroRunning_WhenSkipped_ThenShortBreakStartsNow(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	notifier := &mocks.MockNotifier{}
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock, Notifier: notifier}
+	c.Start()
+	clock.Advance(20 * time.Minute)
+
+	c.Skip()
+
+	if !c.Is(gopomodoro.ShortBreak) {
+		t.Fatalf("expected ShortBreak, got %v", c.Snapshot().State)
+	}
+	if c.Remaining() != 5*time.Minute {
+		t.Fatalf("expected 5m remaining, got %v", c.Remaining())
+	}
+	if notifier.NotifyCallCount != 1 {
+		t.Fatalf("expected NotifyCallCount = 1, got %d", notifier.NotifyCallCount)
+	}
+}
+
+func TestCycle_Given3CompletedPomodoros_When4thIsSkipped_ThenLongBreakStarts(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}}
+	c.Start()
+	for i := 0; i < 3; i++ {
+		mocks.CompleteCycle(c)
+		mocks.CompleteCycle(c)
+	}
+
+	c.Skip()
+
+	if !c.Is(gopomodoro.LongBreak) {
+		t.Fatalf("expected LongBreak, got %v", c.Snapshot().State)
+	}
+}
+
+func TestCycle_GivenShortBreakRunning_WhenSkipped_ThenPomodoroCountIsKept(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}}
+	c.Start()
+	mocks.CompleteCycle(c)
+
+	c.Skip()
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.Pomodoro || snapshot.PomodoroCount != 1 {
+		t.Fatalf("expected Pomodoro with 1 completed, got %+v", snapshot)
+	}
+}
+
+func TestCycle_GivenLongBreakRunning_WhenSkipped_ThenReturnsToIdle(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker}
+	c.Start()
+	for range 7 {
+		mocks.CompleteCycle(c)
+	}
+
+	c.Skip()
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected Idle, got %v", c.Snapshot().State)
+	}
+	if ticker.Started() {
+		t.Fatal("expected ticker to be stopped")
+	}
+}
+
+func TestCycle_GivenPausedPomodoro_WhenSkipped_ThenBreakRuns(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker}
+	c.Start()
+	c.Pause()
+
+	c.Skip()
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.ShortBreak || snapshot.Paused {
+		t.Fatalf("expected running ShortBreak, got %+v", snapshot)
+	}
+	if !ticker.Started() {
+		t.Fatal("expected ticker to be running")
+	}
+}
+
+func TestCycle_GivenIdle_WhenSkipped_ThenNothingHappens(t *testing.T) {
+	observer := &mocks.MockObserver{}
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Observer: observer}
+
+	c.Skip()
+
+	if !c.Is(gopomodoro.Idle) || len(observer.StateChanges) != 0 {
+		t.Fatalf("expected idle cycle without state changes, got %v", observer.StateChanges)
+	}
+}
+
+func TestCycle_GivenConfirmBreaks_WhenPomodoroCompletes_ThenWaitsForStart(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	notifier := &mocks.MockNotifier{}
+	c := &gopomodoro.Cycle{Ticker: ticker, Notifier: notifier, ConfirmBreaks: true}
+	c.Start()
+
+	mocks.CompleteCycle(c)
+	c.AdvanceMinute()
+
+	expected := gopomodoro.Snapshot{
+		State:         gopomodoro.ShortBreak,
+		Remaining:     5 * time.Minute,
+		Waiting:       true,
+		PomodoroCount: 1,
+		Progress:      gopomodoro.Progress{Today: 1, Week: 1},
+	}
+	if snapshot := c.Snapshot(); !reflect.DeepEqual(snapshot, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, snapshot)
+	}
+	if ticker.Started() {
+		t.Fatal("expected ticker to be stopped while waiting")
+	}
+	if notifier.NotifyCallCount != 1 {
+		t.Fatalf("expected NotifyCallCount = 1, got %d", notifier.NotifyCallCount)
+	}
+}
+
+func TestCycle_GivenWaitingForBreak_WhenStartClicked_ThenBreakRuns(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker, ConfirmBreaks: true}
+	c.Start()
+	mocks.CompleteCycle(c)
+
+	c.Start()
+	c.AdvanceMinute()
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.ShortBreak || snapshot.Waiting {
+		t.Fatalf("expected running ShortBreak, got %+v", snapshot)
+	}
+	if snapshot.Remaining != 4*time.Minute {
+		t.Fatalf("expected 4m remaining, got %v", snapshot.Remaining)
+	}
+	if !ticker.Started() {
+		t.Fatal("expected ticker to be running")
+	}
+}
+
+func TestCycle_GivenConfirmPomodorosOnly_WhenBreakCompletes_ThenWaitsBeforeNextPomodoro(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, ConfirmPomodoros: true}
+	c.Start()
+
+	mocks.CompleteCycle(c)
+	if c.Snapshot().Waiting {
+		t.Fatal("expected break to start automatically")
+	}
+	mocks.CompleteCycle(c)
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.Pomodoro || !snapshot.Waiting {
+		t.Fatalf("expected waiting Pomodoro, got %+v", snapshot)
+	}
+}
+
+func TestCycle_GivenWaitingForBreak_WhenStopClicked_ThenReturnsToIdle(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, ConfirmBreaks: true}
+	c.Start()
+	mocks.CompleteCycle(c)
+
+	c.Stop()
+
+	expected := gopomodoro.Snapshot{Progress: gopomodoro.Progress{Today: 1, Week: 1}}
+	if snapshot := c.Snapshot(); !reflect.DeepEqual(snapshot, expected) {
+		t.Fatalf("expected idle snapshot, got %+v", snapshot)
+	}
+}
+
+func TestCycle_GivenWaitingForBreak_WhenSkipped_ThenNextPomodoroRuns(t *testing.T) {
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker, ConfirmBreaks: true}
+	c.Start()
+	mocks.CompleteCycle(c)
+
+	c.Skip()
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.Pomodoro || snapshot.Waiting {
+		t.Fatalf("expected running Pomodoro, got %+v", snapshot)
+	}
+	if !ticker.Started() {
+		t.Fatal("expected ticker to be running")
+	}
+}
+
+func TestCycle_GivenWaitingForPomodoro_WhenSkipped_ThenItDoesNotCount(t *testing.T) {
+	subscriber := &mocks.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, ConfirmPomodoros: true, Goals: gopomodoro.Goals{Daily: 4}}
+	c.SetTask(gopomodoro.Task{Name: "Write report", Estimate: 3})
+	c.Start()
+	mocks.CompleteCycle(c)
+	mocks.CompleteCycle(c)
+	c.Subscribe(subscriber)
+
+	c.Skip()
+
+	s := c.Snapshot()
+	if s.State != gopomodoro.ShortBreak || s.Waiting {
+		t.Fatalf("expected the short break to run, got %+v", s)
+	}
+	if s.PomodoroCount != 1 || s.Progress.Today != 1 || s.Task.Actual != 1 {
+		t.Fatalf("expected only the pomodoro that ran to count, got %+v", s)
+	}
+	if n := subscriber.Count(gopomodoro.PhaseCompleted); n != 0 {
+		t.Fatalf("expected no PhaseCompleted for a pomodoro that never ran, got %d", n)
+	}
+}
+
+func TestCycle_GivenStopAfterPhase_WhenPomodoroCompletes_ThenNotifiesAndReturnsToIdle(t *testing.T) {
+	subscriber := &mocks.MockSubscriber{}
+	notifier := &mocks.MockNotifier{}
+	ticker := &mocks.MockTicker{}
+	c := &gopomodoro.Cycle{Ticker: ticker, Notifier: notifier}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	c.StopAfterPhase()
+	if !c.Snapshot().StopPending {
+		t.Fatal("expected the stop to be pending")
+	}
+	mocks.CompleteCycle(c)
+
+	expected := gopomodoro.Snapshot{Progress: gopomodoro.Progress{Today: 1, Week: 1}}
+	if snapshot := c.Snapshot(); !reflect.DeepEqual(snapshot, expected) {
+		t.Fatalf("expected idle snapshot, got %+v", snapshot)
+	}
+	if notifier.NotifyCallCount != 1 {
+		t.Fatalf("expected the usual notification, got %d", notifier.NotifyCallCount)
+	}
+	expectedTypes := []gopomodoro.EventType{gopomodoro.PhaseStarted, gopomodoro.StopScheduled, gopomodoro.PhaseCompleted, gopomodoro.Halted}
+	if types := subscriber.Types(); !reflect.DeepEqual(types, expectedTypes) {
+		t.Fatalf("expected %v, got %v", expectedTypes, types)
+	}
+	if ticker.Started() {
+		t.Fatal("expected ticker to be stopped")
+	}
+}
+
+func TestCycle_GivenStopAfterPhaseCancelled_WhenPomodoroCompletes_ThenBreakFollows(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}}
+	c.Start()
+	c.StopAfterPhase()
+
+	c.CancelStopAfterPhase()
+	mocks.CompleteCycle(c)
+
+	if snapshot := c.Snapshot(); snapshot.State != gopomodoro.ShortBreak || snapshot.StopPending {
+		t.Fatalf("expected a running short break, got %+v", snapshot)
+	}
+}
+
+func TestCycle_GivenStopAfterPhase_WhenStoppedNow_ThenNextStartRunsThrough(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}}
+	c.Start()
+	c.StopAfterPhase()
+	c.Stop()
+
+	c.Start()
+	mocks.CompleteCycle(c)
+
+	if c.Snapshot().State != gopomodoro.ShortBreak {
+		t.Fatalf("expected the stop request to be cleared by Stop, got %+v", c.Snapshot())
+	}
+}
+
+func TestCycle_GivenIdleOrWaiting_WhenStopAfterPhase_ThenNothingIsPending(t *testing.T) {
+	subscriber := &mocks.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, ConfirmBreaks: true}
+	c.Subscribe(subscriber)
+
+	c.StopAfterPhase()
+	c.Start()
+	mocks.CompleteCycle(c)
+	c.StopAfterPhase()
+
+	if c.Snapshot().StopPending || subscriber.Count(gopomodoro.StopScheduled) != 0 {
+		t.Fatalf("expected no pending stop, got %+v", c.Snapshot())
+	}
+}
+
+func TestCycle_GivenMachineSuspendedForPhases_WhenTicked_ThenCatchesUpWithOneSound(t *testing.T) {
+	clock := mocks.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	notifier := &mocks.MockNotifier{}
+	subscriber := &mocks.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &mocks.MockTicker{}, Clock: clock, Notifier: notifier}
+	c.Subscribe(subscriber)
+	c.Start()
+	clock.Advance(90 * time.Minute)
+
+	c.Tick()
+	for range 9 {
+		clock.Advance(time.Second)
+		c.Tick()
+	}
+
+	s := c.Snapshot()
+	if s.State != gopomodoro.Pomodoro || s.PomodoroCount != 3 || s.Remaining != 25*time.Minute-9*time.Second {
+		t.Fatalf("expected the fourth pomodoro with 24m51s left, got %+v", s)
+	}
+	if n := subscriber.Count(gopomodoro.PhaseCompleted); n != 6 {
+		t.Fatalf("expected the 6 phases that ran out to complete, got %d", n)
+	}
+	if notifier.NotifyCallCount != 1 {
+		t.Fatalf("expected one sound, got %d", notifier.NotifyCallCount)
+	}
+}
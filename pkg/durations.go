@@ -0,0 +1,57 @@
+package gopomodoro
+
+import "time"
+
+// Durations configures the length of each phase and how many pomodoros
+// make up a set. Zero fields fall back to the traditional values.
+type Durations struct {
+	Pomodoro   time.Duration
+	ShortBreak time.Duration
+	LongBreak  time.Duration
+
+	// LongBreakInterval is the number of completed pomodoros before a long break.
+	LongBreakInterval int
+}
+
+// DefaultDurations returns the traditional 25/5/15 rhythm with a long break
+// after every 4 pomodoros.
+func DefaultDurations() Durations {
+	return Durations{
+		Pomodoro:          25 * time.Minute,
+		ShortBreak:        5 * time.Minute,
+		LongBreak:         15 * time.Minute,
+		LongBreakInterval: 4,
+	}
+}
+
+// For returns the configured duration of the given state. Idle has no duration.
+func (d Durations) For(s CycleState) time.Duration {
+	d = d.withDefaults()
+	switch s {
+	case Pomodoro:
+		return d.Pomodoro
+	case ShortBreak:
+		return d.ShortBreak
+	case LongBreak:
+		return d.LongBreak
+	default:
+		return 0
+	}
+}
+
+func (d Durations) withDefaults() Durations {
+	def := DefaultDurations()
+	if d.Pomodoro <= 0 {
+		d.Pomodoro = def.Pomodoro
+	}
+	if d.ShortBreak <= 0 {
+		d.ShortBreak = def.ShortBreak
+	}
+	if d.LongBreak <= 0 {
+		d.LongBreak = def.LongBreak
+	}
+	if d.LongBreakInterval <= 0 {
+		d.LongBreakInterval = def.LongBreakInterval
+	}
+	return d
+}
@@ -0,0 +1,246 @@
+package gopomodoro
+
+import (
+	"strconv"
+	"time"
+)
+
+// EventType identifies what happened in a cycle.
+type EventType int
+
+const (
+	PhaseStarted EventType = iota
+	PhaseCompleted
+	Ticked
+	Paused
+	Resumed
+	// Stopped is emitted when a running phase is abandoned through Stop or
+	// Void. For a pomodoro, Voided and Reason are set.
+	Stopped
+	// Waiting is emitted when a phase completed and the next one, given by
+	// Phase, waits for Start because auto-start is disabled for it.
+	Waiting
+	// SetCompleted is emitted when the long break ends and the cycle
+	// returns to Idle on its own.
+	SetCompleted
+	// Interrupted is emitted when an interruption is recorded against the
+	// running pomodoro.
+	Interrupted
+	// Extended is emitted when the current phase was extended through Extend.
+	Extended
+	// Restored is emitted when a checkpoint was applied through Restore.
+	Restored
+	// TaskChanged is emitted when the task was changed through SetTask.
+	TaskChanged
+	// Overrun is emitted when a pomodoro completes and the task has used up
+	// its estimate, so the next pomodoro on it would exceed the estimate.
+	Overrun
+	// GoalReached is emitted when a completed pomodoro reaches the daily or
+	// weekly goal, given by Goal.
+	GoalReached
+	// ProfileChanged is emitted when a profile chosen through SetProfile
+	// takes effect.
+	ProfileChanged
+	// StopScheduled is emitted when StopAfterPhase was requested for the
+	// running phase, StopCancelled when the request was withdrawn.
+	StopScheduled
+	StopCancelled
+	// Halted is emitted when a phase completed and the cycle returned to
+	// Idle because a stop was scheduled, rather than moving on.
+	Halted
+	// StartRefused is emitted when the cycle's Gate did not admit a
+	// pomodoro; Reason explains why.
+	StartRefused
+	// StartWarned is emitted when the Gate admitted a pomodoro with a
+	// warning, given by Reason. Remaining is the length it was admitted
+	// for, shorter than Planned if it was shortened.
+	StartWarned
+	// IdleDiscarded is emitted when the idle time before PauseIdle was
+	// given back to the pomodoro through DiscardIdle.
+	IdleDiscarded
+	// AbandonRequested is emitted when Stop asks for confirmation through
+	// Abandon before a pomodoro is abandoned in Strict mode.
+	AbandonRequested
+)
+
+func (t EventType) String() string {
+	switch t {
+	case PhaseStarted:
+		return "PhaseStarted"
+	case PhaseCompleted:
+		return "PhaseCompleted"
+	case Ticked:
+		return "Ticked"
+	case Paused:
+		return "Paused"
+	case Resumed:
+		return "Resumed"
+	case Stopped:
+		return "Stopped"
+	case Waiting:
+		return "Waiting"
+	case SetCompleted:
+		return "SetCompleted"
+	case Interrupted:
+		return "Interrupted"
+	case Extended:
+		return "Extended"
+	case Restored:
+		return "Restored"
+	case TaskChanged:
+		return "TaskChanged"
+	case Overrun:
+		return "Overrun"
+	case GoalReached:
+		return "GoalReached"
+	case ProfileChanged:
+		return "ProfileChanged"
+	case StopScheduled:
+		return "StopScheduled"
+	case StopCancelled:
+		return "StopCancelled"
+	case Halted:
+		return "Halted"
+	case StartRefused:
+		return "StartRefused"
+	case StartWarned:
+		return "StartWarned"
+	case IdleDiscarded:
+		return "IdleDiscarded"
+	case AbandonRequested:
+		return "AbandonRequested"
+	default:
+		return "EventType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// Event describes something that happened in a Cycle.
+type Event struct {
+	Type      EventType
+	Time      time.Time
+	Phase     CycleState
+	Remaining time.Duration
+
+	// Pomodoro is the 1-based index of the pomodoro within the current set.
+	// For breaks it is the index of the pomodoro that preceded the break.
+	Pomodoro int
+
+	// Skipped is set on PhaseCompleted when the phase was ended early through
+	// Skip. Remaining then holds the time that was skipped.
+	Skipped bool
+
+	// Interruptions recorded against the pomodoro so far. Only set for
+	// pomodoro events; Interruption is the kind recorded by an Interrupted event.
+	Interruptions Interruptions
+	Interruption  InterruptionKind
+
+	// Started is when the phase started; it is zero while waiting. Planned
+	// is its configured length, excluding extensions.
+	Started time.Time
+	Planned time.Duration
+
+	// Extension is the total time the phase has been extended by so far.
+	Extension time.Duration
+
+	// PausedFor is the total time the phase has spent paused so far.
+	PausedFor time.Duration
+
+	// Voided is set on Stopped when a started pomodoro was abandoned, with
+	// Reason explaining why. Reason also explains StartRefused and
+	// StartWarned events.
+	Voided bool
+	Reason string
+
+	// Elapsed is set on Stopped to the time the phase ran before it was
+	// abandoned, excluding pauses.
+	Elapsed time.Duration
+
+	// Task is the task the cycle was labelled with when the event happened.
+	Task Task
+
+	// Goal is the goal reached by a GoalReached event.
+	Goal GoalPeriod
+
+	// Profile is the profile that took effect with a ProfileChanged event.
+	Profile string
+}
+
+// EventSubscriber receives cycle events.
+type EventSubscriber interface {
+	OnEvent(e Event)
+}
+
+// EventSubscriberFunc adapts a function to an EventSubscriber.
+type EventSubscriberFunc func(e Event)
+
+func (f EventSubscriberFunc) OnEvent(e Event) {
+	f(e)
+}
+
+type subscription struct {
+	id         int
+	subscriber EventSubscriber
+}
+
+// Subscribe registers s for all future events of the cycle. Events are
+// delivered in order, alongside Observer and Notifier callbacks. The returned
+// function unregisters s; it is safe to call more than once.
+func (c *Cycle) Subscribe(s EventSubscriber) (unsubscribe func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextSubscriptionID++
+	id := c.nextSubscriptionID
+	c.subscriptions = append(c.subscriptions, subscription{id: id, subscriber: s})
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i, sub := range c.subscriptions {
+			if sub.id == id {
+				c.subscriptions = append(c.subscriptions[:i:i], c.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// event builds an event of the given type from the current state. Must be
+// called with mu held.
+func (c *Cycle) event(t EventType) Event {
+	pomodoro := c.pomodoroCount
+	if c.State == Pomodoro {
+		pomodoro++
+	}
+	e := Event{
+		Type:      t,
+		Time:      c.now(),
+		Phase:     c.State,
+		Remaining: c.remaining(),
+		Pomodoro:  pomodoro,
+		Started:   c.started,
+		Planned:   c.phaseDuration(c.State),
+		Extension: c.extension,
+		PausedFor: c.pausedFor,
+		Task:      c.task.clone(),
+	}
+	if c.paused {
+		e.PausedFor += e.Time.Sub(c.pausedAt)
+	}
+	if c.State == Pomodoro {
+		e.Interruptions = c.interruptions
+	}
+	return e
+}
+
+// publish queues e for all current subscribers. Must be called with mu held.
+func (c *Cycle) publish(e Event) {
+	for _, sub := range c.subscriptions {
+		subscriber := sub.subscriber
+		c.outbox = append(c.outbox, func() { subscriber.OnEvent(e) })
+	}
+}
+
+// emit publishes an event of the given type built from the current state.
+// Must be called with mu held.
+func (c *Cycle) emit(t EventType) {
+	c.publish(c.event(t))
+}
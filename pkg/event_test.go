@@ -0,0 +1,215 @@
+package gopomodoro_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestEvents_GivenSubscriber_WhenPomodoroCompletes_ThenReceivesTransitionEvents(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	expected := []gopomodoro.EventType{
+		gopomodoro.PhaseStarted,
+		gopomodoro.PhaseCompleted,
+		gopomodoro.PhaseStarted,
+	}
+	if got := subscriber.Types(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	started, _ := subscriber.Last(gopomodoro.PhaseStarted)
+	if started.Phase != gopomodoro.ShortBreak || started.Remaining != 5*time.Minute || started.Pomodoro != 1 {
+		t.Fatalf("expected short break after pomodoro 1 with 5m remaining, got %+v", started)
+	}
+}
+
+func TestEvents_GivenRunningPomodoro_WhenTicked_ThenEventCarriesTimeAndRemaining(t *testing.T) {
+	clock := pomotest.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	clock.Advance(10 * time.Second)
+	c.Tick()
+
+	tick, ok := subscriber.Last(gopomodoro.Ticked)
+	if !ok {
+		t.Fatal("expected a Ticked event")
+	}
+	expected := gopomodoro.Event{
+		Type:      gopomodoro.Ticked,
+		Time:      clock.Now(),
+		Phase:     gopomodoro.Pomodoro,
+		Remaining: 24*time.Minute + 50*time.Second,
+		Pomodoro:  1,
+		Started:   time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+		Planned:   25 * time.Minute,
+	}
+	if !reflect.DeepEqual(tick, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, tick)
+	}
+}
+
+func TestEvents_GivenRunningPomodoro_WhenPausedResumedAndStopped_ThenEmitsEachEvent(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	c.Pause()
+	c.Resume()
+	c.Stop()
+	c.Stop()
+
+	expected := []gopomodoro.EventType{
+		gopomodoro.PhaseStarted,
+		gopomodoro.Paused,
+		gopomodoro.Resumed,
+		gopomodoro.Stopped,
+	}
+	if got := subscriber.Types(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	stopped, _ := subscriber.Last(gopomodoro.Stopped)
+	if stopped.Phase != gopomodoro.Pomodoro {
+		t.Fatalf("expected Stopped event for the abandoned Pomodoro, got %v", stopped.Phase)
+	}
+}
+
+func TestEvents_GivenLongBreakRunning_WhenItEnds_ThenSetCompletedInsteadOfStopped(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	for range 8 {
+		pomotest.CompleteCycle(c)
+	}
+
+	types := subscriber.Types()
+	last := types[len(types)-2:]
+	expected := []gopomodoro.EventType{gopomodoro.PhaseCompleted, gopomodoro.SetCompleted}
+	if !reflect.DeepEqual(last, expected) {
+		t.Fatalf("expected set to end with %v, got %v", expected, types)
+	}
+	if _, ok := subscriber.Last(gopomodoro.Stopped); ok {
+		t.Fatal("expected no Stopped event for a completed set")
+	}
+	completed, _ := subscriber.Last(gopomodoro.SetCompleted)
+	if completed.Pomodoro != 4 {
+		t.Fatalf("expected set to complete after 4 pomodoros, got %d", completed.Pomodoro)
+	}
+}
+
+func TestEvents_GivenTwoSubscribers_WhenOneUnsubscribes_ThenOnlyTheOtherReceivesEvents(t *testing.T) {
+	first := &pomotest.MockSubscriber{}
+	second := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	unsubscribe := c.Subscribe(first)
+	c.Subscribe(second)
+
+	c.Start()
+	unsubscribe()
+	unsubscribe()
+	c.Stop()
+
+	if len(first.Types()) != 1 {
+		t.Fatalf("expected unsubscribed subscriber to receive 1 event, got %v", first.Types())
+	}
+	if len(second.Types()) != 2 {
+		t.Fatalf("expected remaining subscriber to receive 2 events, got %v", second.Types())
+	}
+}
+
+func TestEvents_GivenSubscriberFunc_WhenCycleStarts_ThenFunctionIsCalled(t *testing.T) {
+	var received []gopomodoro.EventType
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(gopomodoro.EventSubscriberFunc(func(e gopomodoro.Event) {
+		received = append(received, e.Type)
+	}))
+
+	c.Start()
+
+	if len(received) != 1 || received[0] != gopomodoro.PhaseStarted {
+		t.Fatalf("expected [PhaseStarted], got %v", received)
+	}
+}
+
+func TestEvents_GivenRunningPomodoro_WhenSkipped_ThenCompletionIsMarkedSkipped(t *testing.T) {
+	clock := pomotest.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock}
+	c.Subscribe(subscriber)
+	c.Start()
+	clock.Advance(20 * time.Minute)
+
+	c.Skip()
+
+	completed, ok := subscriber.Last(gopomodoro.PhaseCompleted)
+	if !ok {
+		t.Fatal("expected a PhaseCompleted event")
+	}
+	if !completed.Skipped || completed.Remaining != 5*time.Minute || completed.Phase != gopomodoro.Pomodoro {
+		t.Fatalf("expected skipped Pomodoro with 5m left, got %+v", completed)
+	}
+}
+
+func TestEvents_GivenConfirmBreaks_WhenPomodoroCompletesAndBreakIsStarted_ThenWaitingPrecedesPhaseStarted(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, ConfirmBreaks: true}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	pomotest.CompleteCycle(c)
+	c.Start()
+
+	expected := []gopomodoro.EventType{
+		gopomodoro.PhaseStarted,
+		gopomodoro.PhaseCompleted,
+		gopomodoro.Waiting,
+		gopomodoro.PhaseStarted,
+	}
+	if got := subscriber.Types(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	waiting, _ := subscriber.Last(gopomodoro.Waiting)
+	if waiting.Phase != gopomodoro.ShortBreak {
+		t.Fatalf("expected Waiting for ShortBreak, got %v", waiting.Phase)
+	}
+}
+
+func TestEvents_GivenPomodoroPausedTwice_WhenCompleted_ThenEventCarriesStartAndPausedTime(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	clock := pomotest.NewMockClock(start)
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock}
+	c.Subscribe(subscriber)
+	c.Start()
+	clock.Advance(5 * time.Minute)
+	c.Pause()
+	clock.Advance(3 * time.Minute)
+	c.Resume()
+	clock.Advance(5 * time.Minute)
+	c.Pause()
+	clock.Advance(2 * time.Minute)
+
+	c.Skip()
+
+	completed, _ := subscriber.First(gopomodoro.PhaseCompleted)
+	if !completed.Started.Equal(start) || completed.PausedFor != 5*time.Minute {
+		t.Fatalf("expected pomodoro started at %v and paused for 5m, got %+v", start, completed)
+	}
+	started, _ := subscriber.Last(gopomodoro.PhaseStarted)
+	if !started.Started.Equal(clock.Now()) || started.PausedFor != 0 || started.Planned != 5*time.Minute {
+		t.Fatalf("expected fresh short break starting now, got %+v", started)
+	}
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+// WriteCSV writes the records as CSV with a header row. Times are RFC 3339
+// and durations are in minutes, so spreadsheets can sum them.
+func WriteCSV(w io.Writer, records []history.Record) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"start", "end", "phase", "minutes", "planned_minutes", "outcome", "task", "tags"})
+	for _, r := range records {
+		_ = cw.Write([]string{
+			r.Start.Format(time.RFC3339),
+			r.End.Format(time.RFC3339),
+			r.Phase.String(),
+			minutes(r.Actual),
+			minutes(r.Planned),
+			string(r.Outcome),
+			r.Task.Name,
+			strings.Join(r.Task.Tags, " "),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// minutes renders d in minutes with up to two decimals, e.g. "25" or "12.5".
+func minutes(d time.Duration) string {
+	return strconv.FormatFloat(math.Round(d.Minutes()*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,140 @@
+// Package export renders the session history for other tools: CSV for
+// spreadsheets, JSON for scripts, iCalendar events for calendars and
+// org-mode CLOCK entries for Emacs.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+// Format names an export format.
+type Format string
+
+const (
+	CSV       Format = "csv"
+	JSON      Format = "json"
+	ICalendar Format = "ics"
+	Org       Format = "org"
+)
+
+// Formats lists the supported formats.
+func Formats() []Format {
+	return []Format{CSV, JSON, ICalendar, Org}
+}
+
+// ParseFormat returns the format called name.
+func ParseFormat(name string) (Format, error) {
+	if f := Format(strings.ToLower(name)); slices.Contains(Formats(), f) {
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown export format %q", name)
+}
+
+// Filter selects records by the time they started and by phase.
+type Filter struct {
+	// From and To bound the start of a record to [From, To). A zero value
+	// leaves that end open.
+	From time.Time
+	To   time.Time
+
+	// Phases lists the phases to keep; empty keeps all of them.
+	Phases []gopomodoro.CycleState
+}
+
+// Apply returns the records that match the filter, in their original order.
+func (f Filter) Apply(records []history.Record) []history.Record {
+	var kept []history.Record
+	for _, r := range records {
+		if !f.From.IsZero() && r.Start.Before(f.From) {
+			continue
+		}
+		if !f.To.IsZero() && !r.Start.Before(f.To) {
+			continue
+		}
+		if len(f.Phases) > 0 && !slices.Contains(f.Phases, r.Phase) {
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// ParsePhases parses a comma-separated list of phases such as
+// "pomodoro,longbreak", ignoring case.
+func ParsePhases(list string) ([]gopomodoro.CycleState, error) {
+	var phases []gopomodoro.CycleState
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		phase, ok := parsePhase(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown phase %q: want pomodoro, shortbreak or longbreak", name)
+		}
+		phases = append(phases, phase)
+	}
+	return phases, nil
+}
+
+func parsePhase(name string) (gopomodoro.CycleState, bool) {
+	for _, phase := range []gopomodoro.CycleState{gopomodoro.Pomodoro, gopomodoro.ShortBreak, gopomodoro.LongBreak} {
+		if strings.EqualFold(name, phase.String()) {
+			return phase, true
+		}
+	}
+	return gopomodoro.Idle, false
+}
+
+// Write renders records in format f.
+func Write(w io.Writer, f Format, records []history.Record) error {
+	switch f {
+	case CSV:
+		return WriteCSV(w, records)
+	case JSON:
+		return WriteJSON(w, records)
+	case ICalendar:
+		return WriteICalendar(w, records)
+	case Org:
+		return WriteOrg(w, records)
+	default:
+		return fmt.Errorf("unknown export format %q", f)
+	}
+}
+
+// WriteJSON writes the records as a JSON array, each in the form of a
+// history line.
+func WriteJSON(w io.Writer, records []history.Record) error {
+	if records == nil {
+		records = []history.Record{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// title names the phase of r, followed by the task for a labelled
+// pomodoro, e.g. "Pomodoro: Write report #docs".
+func title(r history.Record) string {
+	var name string
+	switch r.Phase {
+	case gopomodoro.ShortBreak:
+		name = "Short break"
+	case gopomodoro.LongBreak:
+		name = "Long break"
+	default:
+		name = r.Phase.String()
+	}
+	if r.Phase == gopomodoro.Pomodoro && !r.Task.IsZero() {
+		name += ": " + r.Task.String()
+	}
+	return name
+}
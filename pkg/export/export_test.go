@@ -0,0 +1,127 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/export"
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+var start = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+func records() []history.Record {
+	report := gopomodoro.Task{Name: "Write report", Tags: []string{"docs", "q3-planning"}}
+	return []history.Record{
+		{Start: start, End: start.Add(25 * time.Minute), Phase: gopomodoro.Pomodoro, Planned: 25 * time.Minute, Actual: 25 * time.Minute, Outcome: history.Completed, Task: report},
+		{Start: start.Add(25 * time.Minute), End: start.Add(30 * time.Minute), Phase: gopomodoro.ShortBreak, Planned: 5 * time.Minute, Actual: 5 * time.Minute, Outcome: history.Completed, Task: report},
+		{Start: start.Add(30 * time.Minute), End: start.Add(42*time.Minute + 30*time.Second), Phase: gopomodoro.Pomodoro, Planned: 25 * time.Minute, Actual: 12*time.Minute + 30*time.Second, Outcome: history.Voided, Reason: "phone call", Task: report},
+		{Start: start.Add(time.Hour), End: start.Add(85 * time.Minute), Phase: gopomodoro.Pomodoro, Planned: 25 * time.Minute, Actual: 25 * time.Minute, Outcome: history.Completed},
+	}
+}
+
+func TestFilter_GivenRangeAndPhases_WhenApplied_ThenKeepsMatchingRecords(t *testing.T) {
+	phases, err := export.ParsePhases("Pomodoro, shortbreak")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	filter := export.Filter{From: start.Add(time.Minute), To: start.Add(time.Hour), Phases: phases}
+
+	kept := filter.Apply(records())
+
+	if len(kept) != 2 || kept[0].Phase != gopomodoro.ShortBreak || kept[1].Outcome != history.Voided {
+		t.Fatalf("expected the break and the voided pomodoro, got %+v", kept)
+	}
+	if _, err := export.ParsePhases("pomodoro,nap"); err == nil {
+		t.Fatal("expected an unknown phase to fail")
+	}
+}
+
+func TestWriteCSV_GivenRecords_WhenWritten_ThenOneRowEach(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := export.WriteCSV(&buf, records()[:3]); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "start,end,phase,minutes,planned_minutes,outcome,task,tags\n" +
+		"2026-01-05T09:00:00Z,2026-01-05T09:25:00Z,Pomodoro,25,25,completed,Write report,docs q3-planning\n" +
+		"2026-01-05T09:25:00Z,2026-01-05T09:30:00Z,ShortBreak,5,5,completed,Write report,docs q3-planning\n" +
+		"2026-01-05T09:30:00Z,2026-01-05T09:42:30Z,Pomodoro,12.5,25,voided,Write report,docs q3-planning\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteJSON_GivenNoRecords_WhenWritten_ThenEmptyArray(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := export.WriteJSON(&buf, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Fatalf("expected [], got %q, %v", buf.String(), err)
+	}
+}
+
+func TestWriteJSON_GivenRecords_WhenWritten_ThenDecodesAsHistory(t *testing.T) {
+	var buf bytes.Buffer
+	if err := export.WriteJSON(&buf, records()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var decoded []history.Record
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if len(decoded) != 4 || decoded[2].Reason != "phone call" || decoded[2].Actual != 12*time.Minute+30*time.Second {
+		t.Fatalf("expected the records back, got %+v", decoded)
+	}
+}
+
+func TestWriteICalendar_GivenRecords_WhenWritten_ThenOneEventEach(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := export.WriteICalendar(&buf, records()[2:3]); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//gopomodoro//export//EN\r\n" +
+		"BEGIN:VEVENT\r\nUID:1767605400-pomodoro@gopomodoro\r\nDTSTAMP:20260105T094230Z\r\n" +
+		"DTSTART:20260105T093000Z\r\nDTEND:20260105T094230Z\r\n" +
+		"SUMMARY:Pomodoro: Write report #docs #q3-planning\r\n" +
+		"DESCRIPTION:voided\\, 25m0s planned: phone call\r\n" +
+		"CATEGORIES:docs,q3-planning\r\nTRANSP:TRANSPARENT\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%q\ngot\n%q", expected, buf.String())
+	}
+}
+
+func TestWriteICalendar_GivenLongSummary_WhenWritten_ThenLinesAreFolded(t *testing.T) {
+	r := records()[0]
+	r.Task = gopomodoro.Task{Name: strings.Repeat("Überarbeitung ", 10)}
+	var buf bytes.Buffer
+
+	_ = export.WriteICalendar(&buf, []history.Record{r})
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("expected lines of at most 75 octets, got %d: %q", len(line), line)
+		}
+	}
+	if !strings.Contains(buf.String(), "\r\n ") {
+		t.Fatal("expected a folded line")
+	}
+}
+
+func TestParseFormat_GivenNames_WhenParsed_ThenKnownOnesAccepted(t *testing.T) {
+	for _, f := range export.Formats() {
+		if parsed, err := export.ParseFormat(strings.ToUpper(string(f))); err != nil || parsed != f {
+			t.Fatalf("expected %v, got %v, %v", f, parsed, err)
+		}
+	}
+	if _, err := export.ParseFormat("xlsx"); err == nil {
+		t.Fatal("expected an unknown format to fail")
+	}
+}
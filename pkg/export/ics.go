@@ -0,0 +1,81 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+// icsTime is the UTC form of an iCalendar DATE-TIME.
+const icsTime = "20060102T150405Z"
+
+// WriteICalendar writes the records as a calendar with one VEVENT each,
+// so they can be imported next to the meetings they happened between.
+func WriteICalendar(w io.Writer, records []history.Record) error {
+	ew := &errWriter{w: w}
+	ew.line("BEGIN:VCALENDAR")
+	ew.line("VERSION:2.0")
+	ew.line("PRODID:-//gopomodoro//export//EN")
+	for _, r := range records {
+		ew.line("BEGIN:VEVENT")
+		ew.line(fmt.Sprintf("UID:%d-%s@gopomodoro", r.Start.Unix(), strings.ToLower(r.Phase.String())))
+		ew.line("DTSTAMP:" + r.End.UTC().Format(icsTime))
+		ew.line("DTSTART:" + r.Start.UTC().Format(icsTime))
+		ew.line("DTEND:" + r.End.UTC().Format(icsTime))
+		ew.line("SUMMARY:" + escapeText(title(r)))
+		ew.line("DESCRIPTION:" + escapeText(describe(r)))
+		if len(r.Task.Tags) > 0 {
+			tags := make([]string, len(r.Task.Tags))
+			for i, tag := range r.Task.Tags {
+				tags[i] = escapeText(tag)
+			}
+			ew.line("CATEGORIES:" + strings.Join(tags, ","))
+		}
+		ew.line("TRANSP:TRANSPARENT")
+		ew.line("END:VEVENT")
+	}
+	ew.line("END:VCALENDAR")
+	return ew.err
+}
+
+// describe summarises how the phase went, e.g. "completed, 25m planned".
+func describe(r history.Record) string {
+	d := fmt.Sprintf("%s, %s planned", r.Outcome, r.Planned)
+	if r.Reason != "" {
+		d += ": " + r.Reason
+	}
+	return d
+}
+
+// escapeText escapes a TEXT value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// errWriter writes iCalendar content lines, folded at 75 octets and ended
+// with CRLF, keeping the first error.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) line(s string) {
+	if ew.err != nil {
+		return
+	}
+	var b strings.Builder
+	// Continuation lines start with a space, leaving room for 74 octets.
+	for limit := 75; len(s) > limit; limit = 74 {
+		cut := limit
+		// Do not split a UTF-8 sequence.
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+	}
+	b.WriteString(s + "\r\n")
+	_, ew.err = io.WriteString(ew.w, b.String())
+}
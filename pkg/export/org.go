@@ -0,0 +1,90 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+// orgTime is an inactive org-mode timestamp.
+const orgTime = "[2006-01-02 Mon 15:04]"
+
+// orgHeading identifies the heading a record is clocked under.
+type orgHeading struct {
+	title   string
+	outcome history.Outcome
+}
+
+// WriteOrg writes the records as org-mode CLOCK entries in a LOGBOOK
+// drawer, under one heading per task for pomodoros and per kind of break.
+// Phases that did not complete get a heading of their own per outcome,
+// which an OUTCOME property names. Headings appear in the order they first
+// occur; the entries under each are newest first, as org-mode keeps them.
+// Tags become org tags.
+func WriteOrg(w io.Writer, records []history.Record) error {
+	var headings []orgHeading
+	clocks := map[orgHeading][]history.Record{}
+	tags := map[orgHeading][]string{}
+	for _, r := range records {
+		heading := orgHeading{title: title(r), outcome: r.Outcome}
+		if r.Phase == gopomodoro.Pomodoro && r.Task.Name != "" {
+			heading.title = r.Task.Name
+		}
+		if _, ok := clocks[heading]; !ok {
+			headings = append(headings, heading)
+			if r.Phase == gopomodoro.Pomodoro {
+				tags[heading] = r.Task.Tags
+			}
+		}
+		clocks[heading] = append(clocks[heading], r)
+	}
+
+	var b strings.Builder
+	for _, heading := range headings {
+		b.WriteString("* " + heading.title)
+		if t := tags[heading]; len(t) > 0 {
+			b.WriteString(" :" + strings.Join(orgTags(t), ":") + ":")
+		}
+		b.WriteString("\n")
+		if heading.outcome != history.Completed {
+			b.WriteString(":PROPERTIES:\n:OUTCOME: " + string(heading.outcome) + "\n:END:\n")
+		}
+		b.WriteString(":LOGBOOK:\n")
+		entries := clocks[heading]
+		for i := len(entries) - 1; i >= 0; i-- {
+			r := entries[i]
+			fmt.Fprintf(&b, "CLOCK: %s--%s => %s\n", r.Start.Format(orgTime), r.End.Format(orgTime), orgDuration(r.End.Sub(r.Start)))
+		}
+		b.WriteString(":END:\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// orgDuration renders d the way org-mode sums up a clock, e.g. " 0:25".
+func orgDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	return fmt.Sprintf("%2d:%02d", minutes/60, minutes%60)
+}
+
+// orgTags makes tags valid org tags, which may only hold letters, digits,
+// "_" and "@".
+func orgTags(tags []string) []string {
+	valid := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.Map(func(r rune) rune {
+			switch {
+			case r == '_' || r == '@' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 127:
+				return r
+			default:
+				return '_'
+			}
+		}, tag)
+		valid = append(valid, tag)
+	}
+	return valid
+}
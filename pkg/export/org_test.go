@@ -0,0 +1,59 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/export"
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+func TestWriteOrg_GivenRecords_WhenWritten_ThenClocksUnderHeadings(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := export.WriteOrg(&buf, records()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "* Write report :docs:q3_planning:\n:LOGBOOK:\n" +
+		"CLOCK: [2026-01-05 Mon 09:00]--[2026-01-05 Mon 09:25] =>  0:25\n" +
+		":END:\n" +
+		"* Short break\n:LOGBOOK:\n" +
+		"CLOCK: [2026-01-05 Mon 09:25]--[2026-01-05 Mon 09:30] =>  0:05\n" +
+		":END:\n" +
+		"* Write report :docs:q3_planning:\n:PROPERTIES:\n:OUTCOME: voided\n:END:\n:LOGBOOK:\n" +
+		"CLOCK: [2026-01-05 Mon 09:30]--[2026-01-05 Mon 09:42] =>  0:13\n" +
+		":END:\n" +
+		"* Pomodoro\n:LOGBOOK:\n" +
+		"CLOCK: [2026-01-05 Mon 10:00]--[2026-01-05 Mon 10:25] =>  0:25\n" +
+		":END:\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteOrg_GivenPhasesEndedEarly_WhenWritten_ThenGroupedByOutcome(t *testing.T) {
+	var buf bytes.Buffer
+	records := []history.Record{
+		{Start: start, End: start.Add(10 * time.Minute), Phase: gopomodoro.Pomodoro, Outcome: history.Stopped},
+		{Start: start.Add(10 * time.Minute), End: start.Add(12 * time.Minute), Phase: gopomodoro.ShortBreak, Outcome: history.Skipped},
+		{Start: start.Add(12 * time.Minute), End: start.Add(20 * time.Minute), Phase: gopomodoro.Pomodoro, Outcome: history.Stopped},
+	}
+
+	if err := export.WriteOrg(&buf, records); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "* Pomodoro\n:PROPERTIES:\n:OUTCOME: stopped\n:END:\n:LOGBOOK:\n" +
+		"CLOCK: [2026-01-05 Mon 09:12]--[2026-01-05 Mon 09:20] =>  0:08\n" +
+		"CLOCK: [2026-01-05 Mon 09:00]--[2026-01-05 Mon 09:10] =>  0:10\n" +
+		":END:\n" +
+		"* Short break\n:PROPERTIES:\n:OUTCOME: skipped\n:END:\n:LOGBOOK:\n" +
+		"CLOCK: [2026-01-05 Mon 09:10]--[2026-01-05 Mon 09:12] =>  0:02\n" +
+		":END:\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,98 @@
+package gopomodoro
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotRunning is returned when an operation needs a started phase.
+	ErrNotRunning = errors.New("no phase is running")
+	// ErrExtensionLimit is returned when an extension would exceed the
+	// phase's limit.
+	ErrExtensionLimit = errors.New("extension limit reached")
+)
+
+// ExtensionLimits caps the total time each phase can be extended by. Zero
+// fields fall back to DefaultExtensionLimits; a negative limit disables
+// extending that phase.
+type ExtensionLimits struct {
+	Pomodoro   time.Duration
+	ShortBreak time.Duration
+	LongBreak  time.Duration
+}
+
+// DefaultExtensionLimits allows finishing a thought without turning a
+// pomodoro into an open-ended work session.
+func DefaultExtensionLimits() ExtensionLimits {
+	return ExtensionLimits{
+		Pomodoro:   10 * time.Minute,
+		ShortBreak: 5 * time.Minute,
+		LongBreak:  15 * time.Minute,
+	}
+}
+
+// For returns the extension limit of the given state. Idle cannot be extended.
+func (l ExtensionLimits) For(s CycleState) time.Duration {
+	def := DefaultExtensionLimits()
+	var limit, fallback time.Duration
+	switch s {
+	case Pomodoro:
+		limit, fallback = l.Pomodoro, def.Pomodoro
+	case ShortBreak:
+		limit, fallback = l.ShortBreak, def.ShortBreak
+	case LongBreak:
+		limit, fallback = l.LongBreak, def.LongBreak
+	default:
+		return 0
+	}
+	if limit == 0 {
+		return fallback
+	}
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// ExtensionLimit returns how much the given phase can be extended in total.
+func (c *Cycle) ExtensionLimit(s CycleState) time.Duration {
+	return c.ExtensionLimits.For(s)
+}
+
+// Extend adds d to the current phase without restarting it. The total
+// extension of a phase is capped by ExtensionLimits; an extension that would
+// exceed the cap is refused with ErrExtensionLimit. Running and paused phases
+// can be extended; otherwise ErrNotRunning is returned.
+func (c *Cycle) Extend(d time.Duration) error {
+	c.mu.Lock()
+	err := c.extend(d)
+	c.mu.Unlock()
+	c.deliver()
+	return err
+}
+
+func (c *Cycle) extend(d time.Duration) error {
+	if c.State == Idle || c.waiting {
+		return ErrNotRunning
+	}
+	if d <= 0 {
+		return nil
+	}
+	if c.extension+d > c.ExtensionLimit(c.State) {
+		return ErrExtensionLimit
+	}
+	c.extension += d
+	if c.paused {
+		c.TimeLeft += d
+	} else {
+		if c.deadline.IsZero() {
+			c.deadline = c.now().Add(c.TimeLeft)
+		}
+		c.deadline = c.deadline.Add(d)
+		c.TimeLeft = c.remaining()
+	}
+	c.emit(Extended)
+	c.notifyStateChanged()
+	return nil
+}
@@ -0,0 +1,127 @@
+package gopomodoro_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestExtend_GivenRunningPomodoro_WhenExtended_ThenDeadlineMovesWithoutRestart(t *testing.T) {
+	clock := pomotest.NewMockClock(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock}
+	c.Start()
+	clock.Advance(23 * time.Minute)
+
+	if err := c.Extend(5 * time.Minute); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.Pomodoro || snapshot.Remaining != 7*time.Minute {
+		t.Fatalf("expected Pomodoro with 7m remaining, got %+v", snapshot)
+	}
+	if snapshot.Extension != 5*time.Minute {
+		t.Fatalf("expected 5m extension, got %v", snapshot.Extension)
+	}
+}
+
+func TestExtend_GivenCycleConstructedRunning_WhenExtended_ThenDeadlineFollowsTimeLeft(t *testing.T) {
+	c, _, _ := pomotest.NewCycle(func(c *gopomodoro.Cycle) {
+		c.State = gopomodoro.Pomodoro
+		c.TimeLeft = 3 * time.Minute
+	})
+
+	if err := c.Extend(time.Minute); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	c.Tick()
+
+	if s := c.Snapshot(); s.State != gopomodoro.Pomodoro || s.Remaining != 4*time.Minute {
+		t.Fatalf("expected Pomodoro with 4m remaining, got %+v", s)
+	}
+}
+
+func TestExtend_GivenPausedPomodoro_WhenExtended_ThenFrozenTimeGrows(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+	c.AdvanceMinute()
+	c.Pause()
+
+	if err := c.Extend(time.Minute); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if c.Remaining() != 25*time.Minute {
+		t.Fatalf("expected 25m remaining, got %v", c.Remaining())
+	}
+}
+
+func TestExtend_GivenLimitAlmostReached_WhenExtendedPastIt_ThenRefused(t *testing.T) {
+	c := &gopomodoro.Cycle{
+		Ticker:          &pomotest.MockTicker{},
+		ExtensionLimits: gopomodoro.ExtensionLimits{Pomodoro: 5 * time.Minute},
+	}
+	c.Start()
+	_ = c.Extend(3 * time.Minute)
+
+	err := c.Extend(5 * time.Minute)
+
+	if !errors.Is(err, gopomodoro.ErrExtensionLimit) {
+		t.Fatalf("expected ErrExtensionLimit, got %v", err)
+	}
+	if c.Remaining() != 28*time.Minute {
+		t.Fatalf("expected refused extension to leave 28m remaining, got %v", c.Remaining())
+	}
+}
+
+func TestExtend_GivenNegativeLimit_WhenExtended_ThenRefused(t *testing.T) {
+	c := &gopomodoro.Cycle{
+		Ticker:          &pomotest.MockTicker{},
+		ExtensionLimits: gopomodoro.ExtensionLimits{Pomodoro: -1},
+	}
+	c.Start()
+
+	err := c.Extend(time.Minute)
+
+	if !errors.Is(err, gopomodoro.ErrExtensionLimit) {
+		t.Fatalf("expected ErrExtensionLimit, got %v", err)
+	}
+}
+
+func TestExtend_GivenIdle_WhenExtended_ThenNotRunning(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+
+	err := c.Extend(time.Minute)
+
+	if !errors.Is(err, gopomodoro.ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning, got %v", err)
+	}
+}
+
+func TestExtend_GivenExtendedPomodoro_WhenCompleted_ThenBreakStartsWithoutExtension(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+	_ = c.Extend(2 * time.Minute)
+
+	for range 27 {
+		c.AdvanceMinute()
+	}
+
+	extended, ok := subscriber.Last(gopomodoro.Extended)
+	if !ok || extended.Extension != 2*time.Minute {
+		t.Fatalf("expected Extended event with 2m, got %+v", extended)
+	}
+	completed, _ := subscriber.First(gopomodoro.PhaseCompleted)
+	if completed.Phase != gopomodoro.Pomodoro || completed.Extension != 2*time.Minute {
+		t.Fatalf("expected completed pomodoro to record 2m extension, got %+v", completed)
+	}
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.ShortBreak || snapshot.Extension != 0 {
+		t.Fatalf("expected ShortBreak without extension, got %+v", snapshot)
+	}
+}
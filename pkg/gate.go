@@ -0,0 +1,105 @@
+package gopomodoro
+
+import (
+	"strings"
+	"time"
+)
+
+// StartGate decides whether a pomodoro may start. The cycle consults it
+// before every pomodoro, whether started through Start or automatically
+// after a break.
+type StartGate interface {
+	// AdmitPomodoro decides whether a pomodoro of length d may start at t.
+	// A refusal is returned as an error explaining why.
+	AdmitPomodoro(t time.Time, d time.Duration) (Admission, error)
+}
+
+// Admission admits a pomodoro, possibly shortened or with a warning.
+type Admission struct {
+	// Length is how long the pomodoro may run, at most the length asked for.
+	Length time.Duration
+
+	// Warning explains a shortened length or a conflict the pomodoro is
+	// admitted despite.
+	Warning string
+}
+
+// Admit admits a pomodoro of length d as it is.
+func Admit(d time.Duration) Admission {
+	return Admission{Length: d}
+}
+
+// StartGates admits a pomodoro only if every gate does. Each gate is asked
+// for the length the gates before it admitted, and their warnings are
+// joined.
+type StartGates []StartGate
+
+func (g StartGates) AdmitPomodoro(t time.Time, d time.Duration) (Admission, error) {
+	var warnings []string
+	for _, gate := range g {
+		a, err := gate.AdmitPomodoro(t, d)
+		if err != nil {
+			return Admission{}, err
+		}
+		d = min(d, a.Length)
+		if a.Warning != "" {
+			warnings = append(warnings, a.Warning)
+		}
+	}
+	return Admission{Length: d, Warning: strings.Join(warnings, "; ")}, nil
+}
+
+// StartBlocked returns why Start would not start a pomodoro right now, or
+// nil if it would or if Start would not start a pomodoro at all.
+func (c *Cycle) StartBlocked() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.paused || !(c.State == Idle || c.waiting && c.State == Pomodoro) {
+		return nil
+	}
+	_, err := c.admission(c.now(), c.phaseDuration(Pomodoro))
+	return err
+}
+
+// admission asks the BreakPolicy and then the Gate whether a pomodoro of
+// length d may start at t. Must be called with mu held.
+func (c *Cycle) admission(t time.Time, d time.Duration) (Admission, error) {
+	if err := c.admitRest(t); err != nil {
+		return Admission{}, err
+	}
+	if c.Gate == nil {
+		return Admit(d), nil
+	}
+	return c.Gate.AdmitPomodoro(t, d)
+}
+
+// admitPomodoro asks the BreakPolicy and the Gate whether a pomodoro may
+// start at t and returns the length it may run. A refusal emits
+// StartRefused, a warning StartWarned; either is kept as the notice until
+// the phase ends. Must be called with mu held.
+func (c *Cycle) admitPomodoro(t time.Time) (time.Duration, bool) {
+	d := c.phaseDuration(Pomodoro)
+	a, err := c.admission(t, d)
+	switch {
+	case err != nil:
+		c.notice = err.Error()
+		refused := c.event(StartRefused)
+		refused.Phase = Pomodoro
+		refused.Planned = d
+		refused.Reason = c.notice
+		c.publish(refused)
+		c.notifyStateChanged()
+		return 0, false
+	case a.Warning != "":
+		c.notice = a.Warning
+		warned := c.event(StartWarned)
+		warned.Phase = Pomodoro
+		warned.Planned = d
+		warned.Remaining = min(d, a.Length)
+		warned.Reason = c.notice
+		c.publish(warned)
+	default:
+		c.notice = ""
+	}
+	return min(d, a.Length), true
+}
@@ -0,0 +1,136 @@
+package gopomodoro_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+// closingGate admits pomodoros that end by closes.
+type closingGate struct {
+	closes time.Time
+}
+
+func (g closingGate) AdmitPomodoro(t time.Time, d time.Duration) (gopomodoro.Admission, error) {
+	if t.Add(d).After(g.closes) {
+		return gopomodoro.Admission{}, errors.New("closing soon")
+	}
+	return gopomodoro.Admit(d), nil
+}
+
+// shorteningGate admits pomodoros of at most max, warning when it shortens
+// one.
+type shorteningGate struct {
+	max time.Duration
+}
+
+func (g shorteningGate) AdmitPomodoro(t time.Time, d time.Duration) (gopomodoro.Admission, error) {
+	if d <= g.max {
+		return gopomodoro.Admit(d), nil
+	}
+	return gopomodoro.Admission{Length: g.max, Warning: "meeting soon"}, nil
+}
+
+func TestGate_GivenRefusingGate_WhenStartedFromIdle_ThenStaysIdleAndExplains(t *testing.T) {
+	ticker := &pomotest.MockTicker{}
+	c, _, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) {
+		c.Ticker = ticker
+		c.Gate = closingGate{closes: pomotest.StartTime.Add(20 * time.Minute)}
+	})
+
+	c.Start()
+
+	if !c.Is(gopomodoro.Idle) || ticker.Started() {
+		t.Fatalf("expected to stay idle, got %+v", c.Snapshot())
+	}
+	refused, ok := subscriber.Last(gopomodoro.StartRefused)
+	if !ok || refused.Reason != "closing soon" || refused.Phase != gopomodoro.Pomodoro || refused.Planned != 25*time.Minute {
+		t.Fatalf("expected a StartRefused event, got %+v", refused)
+	}
+	if err := c.StartBlocked(); err == nil {
+		t.Fatal("expected StartBlocked to report the gate's reason")
+	}
+}
+
+func TestGate_GivenBreakEndingTooLate_WhenNextPomodoroDue_ThenWaitsForStart(t *testing.T) {
+	c, clock, _ := pomotest.NewCycle(func(c *gopomodoro.Cycle) {
+		c.Gate = closingGate{closes: pomotest.StartTime.Add(50 * time.Minute)}
+	})
+	c.Start()
+
+	clock.Advance(25 * time.Minute)
+	c.Tick()
+	clock.Advance(5 * time.Minute)
+	c.Tick()
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.Pomodoro || !snapshot.Waiting {
+		t.Fatalf("expected the next pomodoro to wait, got %+v", snapshot)
+	}
+	c.Start()
+	if !c.Snapshot().Waiting {
+		t.Fatal("expected Start to be refused too")
+	}
+}
+
+func TestGate_GivenAdmittingGate_WhenStarted_ThenRuns(t *testing.T) {
+	c, _, _ := pomotest.NewCycle(func(c *gopomodoro.Cycle) {
+		c.Gate = gopomodoro.StartGates{closingGate{closes: pomotest.StartTime.Add(time.Hour)}}
+	})
+
+	c.Start()
+
+	if !c.Is(gopomodoro.Pomodoro) || c.StartBlocked() != nil {
+		t.Fatalf("expected a running pomodoro, got %+v", c.Snapshot())
+	}
+}
+
+func TestStartGates_GivenOneRefusing_WhenAsked_ThenRefuses(t *testing.T) {
+	gates := gopomodoro.StartGates{
+		closingGate{closes: pomotest.StartTime.Add(time.Hour)},
+		closingGate{closes: pomotest.StartTime.Add(10 * time.Minute)},
+	}
+
+	_, err := gates.AdmitPomodoro(pomotest.StartTime, 25*time.Minute)
+
+	if !reflect.DeepEqual(err, errors.New("closing soon")) {
+		t.Fatalf("expected the second gate's reason, got %v", err)
+	}
+}
+
+func TestGate_GivenShorteningGate_WhenStarted_ThenPomodoroRunsShortWithNotice(t *testing.T) {
+	c, _, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) {
+		c.Gate = shorteningGate{max: 15 * time.Minute}
+	})
+
+	c.Start()
+
+	snapshot := c.Snapshot()
+	if snapshot.State != gopomodoro.Pomodoro || snapshot.Remaining != 15*time.Minute || snapshot.Notice != "meeting soon" {
+		t.Fatalf("expected a 15m pomodoro with a notice, got %+v", snapshot)
+	}
+	warned, ok := subscriber.Last(gopomodoro.StartWarned)
+	if !ok || warned.Remaining != 15*time.Minute || warned.Planned != 25*time.Minute {
+		t.Fatalf("expected a StartWarned event, got %+v", warned)
+	}
+	for range 15 {
+		c.AdvanceMinute()
+	}
+	if snapshot := c.Snapshot(); snapshot.State != gopomodoro.ShortBreak || snapshot.Notice != "" {
+		t.Fatalf("expected the break to follow with the notice cleared, got %+v", snapshot)
+	}
+}
+
+func TestStartGates_GivenSeveralShortening_WhenAsked_ThenShortestWinsAndWarningsJoin(t *testing.T) {
+	gates := gopomodoro.StartGates{shorteningGate{max: 20 * time.Minute}, shorteningGate{max: 10 * time.Minute}}
+
+	a, err := gates.AdmitPomodoro(pomotest.StartTime, 25*time.Minute)
+
+	if err != nil || a.Length != 10*time.Minute || a.Warning != "meeting soon; meeting soon" {
+		t.Fatalf("expected 10m with both warnings, got %+v, %v", a, err)
+	}
+}
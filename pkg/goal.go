@@ -0,0 +1,95 @@
+package gopomodoro
+
+import "time"
+
+// Goals sets how many pomodoros to complete per day and per week, counted
+// independently of the set. Zero disables a goal.
+type Goals struct {
+	Daily  int
+	Weekly int
+
+	// DayStart is the time after midnight at which a day begins. Weeks
+	// begin on Monday at DayStart.
+	DayStart time.Duration
+}
+
+// GoalPeriod tells the daily goal apart from the weekly one.
+type GoalPeriod int
+
+const (
+	DailyGoal GoalPeriod = iota
+	WeeklyGoal
+)
+
+func (p GoalPeriod) String() string {
+	if p == WeeklyGoal {
+		return "weekly"
+	}
+	return "daily"
+}
+
+// Progress counts the pomodoros completed towards the goals.
+type Progress struct {
+	Today int
+	Week  int
+	Goals Goals
+}
+
+// progress tracks the completed pomodoros of the current day and week.
+type progress struct {
+	today, week       int
+	dayFrom, weekFrom time.Time
+}
+
+// SetProgress seeds the pomodoros completed so far today and this week,
+// typically counted from the history when the process starts. Reaching a
+// goal through SetProgress does not emit GoalReached.
+func (c *Cycle) SetProgress(today, week int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rollProgress(c.now())
+	c.progress.today = today
+	c.progress.week = week
+}
+
+// snapshotProgress returns the progress as of now. Must be called with mu held.
+func (c *Cycle) snapshotProgress() Progress {
+	c.rollProgress(c.now())
+	return Progress{Today: c.progress.today, Week: c.progress.week, Goals: c.Goals}
+}
+
+// rollProgress starts counting afresh when t is in a later day or week than
+// the counts so far. Must be called with mu held.
+func (c *Cycle) rollProgress(t time.Time) {
+	day, week := DayOf(t, c.Goals.DayStart), WeekOf(t, c.Goals.DayStart)
+	if !day.Equal(c.progress.dayFrom) {
+		c.progress.dayFrom = day
+		c.progress.today = 0
+	}
+	if !week.Equal(c.progress.weekFrom) {
+		c.progress.weekFrom = week
+		c.progress.week = 0
+	}
+}
+
+// countGoals counts a completed pomodoro towards the goals, emitting
+// GoalReached and notifying when it is the one that reaches a goal. Must be
+// called with mu held.
+func (c *Cycle) countGoals() {
+	c.rollProgress(c.now())
+	c.progress.today++
+	c.progress.week++
+	if c.Goals.Daily > 0 && c.progress.today == c.Goals.Daily {
+		c.reachGoal(DailyGoal)
+	}
+	if c.Goals.Weekly > 0 && c.progress.week == c.Goals.Weekly {
+		c.reachGoal(WeeklyGoal)
+	}
+}
+
+func (c *Cycle) reachGoal(p GoalPeriod) {
+	reached := c.event(GoalReached)
+	reached.Goal = p
+	c.publish(reached)
+	c.notify()
+}
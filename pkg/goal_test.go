@@ -0,0 +1,81 @@
+package gopomodoro_test
+
+import (
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestGoals_GivenDailyGoal_WhenReached_ThenEmitsGoalReachedAndNotifiesOnce(t *testing.T) {
+	notifier := &pomotest.MockNotifier{}
+	c, _, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) {
+		c.Notifier = notifier
+		c.Goals = gopomodoro.Goals{Daily: 2}
+	})
+	c.SetProgress(1, 1)
+	c.Start()
+
+	pomotest.CompleteCycle(c)
+	pomotest.CompleteCycle(c)
+	pomotest.CompleteCycle(c)
+
+	reached, ok := subscriber.Last(gopomodoro.GoalReached)
+	if !ok || reached.Goal != gopomodoro.DailyGoal {
+		t.Fatalf("expected daily goal reached, got %+v", reached)
+	}
+	if n := subscriber.Count(gopomodoro.GoalReached); n != 1 {
+		t.Fatalf("expected 1 GoalReached, got %d", n)
+	}
+	// One notification per completed phase plus the one for the goal.
+	if notifier.NotifyCallCount != 4 {
+		t.Fatalf("expected 4 notifications, got %d", notifier.NotifyCallCount)
+	}
+	if progress := c.Snapshot().Progress; progress.Today != 3 || progress.Week != 3 {
+		t.Fatalf("expected 3 pomodoros today and this week, got %+v", progress)
+	}
+}
+
+func TestGoals_GivenProgress_WhenNextDayStarts_ThenTodayResetsButWeekKeepsCounting(t *testing.T) {
+	c, clock, _ := pomotest.NewCycle(func(c *gopomodoro.Cycle) { c.Goals = gopomodoro.Goals{DayStart: 4 * time.Hour} })
+	c.SetProgress(5, 12)
+
+	clock.Advance(17 * time.Hour) // Tuesday 02:00 still belongs to Monday
+	if progress := c.Snapshot().Progress; progress.Today != 5 {
+		t.Fatalf("expected Monday's 5 pomodoros before 04:00, got %+v", progress)
+	}
+	clock.Advance(3 * time.Hour)
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	if progress := c.Snapshot().Progress; progress.Today != 1 || progress.Week != 13 {
+		t.Fatalf("expected 1 today and 13 this week, got %+v", progress)
+	}
+}
+
+func TestGoals_GivenDailyGoal_WhenLastPomodoroSkipped_ThenNotReached(t *testing.T) {
+	c, _, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) { c.Goals = gopomodoro.Goals{Daily: 2} })
+	c.SetProgress(1, 1)
+	c.Start()
+
+	c.Skip()
+
+	if _, ok := subscriber.Last(gopomodoro.GoalReached); ok {
+		t.Fatal("expected a skipped pomodoro not to reach the goal")
+	}
+	if progress := c.Snapshot().Progress; progress.Today != 1 || progress.Week != 1 {
+		t.Fatalf("expected progress to stay at 1, got %+v", progress)
+	}
+}
+
+func TestGoals_GivenProgress_WhenNextWeekStarts_ThenWeekResets(t *testing.T) {
+	c, clock, _ := pomotest.NewCycle(nil)
+	c.SetProgress(5, 30)
+
+	clock.Advance(7 * 24 * time.Hour)
+
+	if progress := c.Snapshot().Progress; progress.Today != 0 || progress.Week != 0 {
+		t.Fatalf("expected a fresh week, got %+v", progress)
+	}
+}
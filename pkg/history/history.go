@@ -0,0 +1,177 @@
+// Package history records finished phases of a cycle in an append-only log.
+package history
+
+import (
+	"encoding/json"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/internal/textduration"
+)
+
+// Outcome tells how a phase ended.
+type Outcome string
+
+const (
+	Completed Outcome = "completed"
+	// Skipped phases were ended early through Cycle.Skip.
+	Skipped Outcome = "skipped"
+	// Voided pomodoros were abandoned before they completed.
+	Voided Outcome = "voided"
+	// Stopped breaks were abandoned through Cycle.Stop.
+	Stopped Outcome = "stopped"
+)
+
+// Record describes one finished phase.
+type Record struct {
+	Start time.Time
+	End   time.Time
+	Phase gopomodoro.CycleState
+
+	// Pomodoro is the 1-based index of the pomodoro within its set; for
+	// breaks, the index of the pomodoro before the break.
+	Pomodoro int
+
+	// Planned is the configured length of the phase. Actual is the time it
+	// ran, excluding pauses and including extensions.
+	Planned   time.Duration
+	Actual    time.Duration
+	Extension time.Duration
+	Paused    time.Duration
+
+	Outcome       Outcome
+	Reason        string
+	Interruptions gopomodoro.Interruptions
+
+	// Elapsed is the time a stopped or voided phase ran before it was
+	// abandoned, excluding pauses.
+	Elapsed time.Duration
+
+	// Task is the task the phase was labelled with, if any.
+	Task gopomodoro.Task
+}
+
+// FromEvent builds the record of the phase that ended with e. It reports
+// false for events that do not end a phase.
+func FromEvent(e gopomodoro.Event) (Record, bool) {
+	var outcome Outcome
+	switch {
+	case e.Type == gopomodoro.PhaseCompleted && e.Skipped:
+		outcome = Skipped
+	case e.Type == gopomodoro.PhaseCompleted:
+		outcome = Completed
+	case e.Type == gopomodoro.Stopped && e.Voided:
+		outcome = Voided
+	case e.Type == gopomodoro.Stopped:
+		outcome = Stopped
+	default:
+		return Record{}, false
+	}
+
+	actual := e.Planned + e.Extension - e.Remaining
+	start := e.Started
+	if start.IsZero() {
+		start = e.Time.Add(-actual - e.PausedFor)
+	} else {
+		actual = e.Time.Sub(start) - e.PausedFor
+	}
+	return Record{
+		Start:         start,
+		End:           e.Time,
+		Phase:         e.Phase,
+		Pomodoro:      e.Pomodoro,
+		Planned:       e.Planned,
+		Actual:        actual,
+		Extension:     e.Extension,
+		Paused:        e.PausedFor,
+		Outcome:       outcome,
+		Reason:        e.Reason,
+		Interruptions: e.Interruptions,
+		Elapsed:       e.Elapsed,
+		Task:          e.Task,
+	}, true
+}
+
+// line is the JSON form of a Record, with durations written as strings
+// such as "25m0s".
+type line struct {
+	Start     time.Time             `json:"start"`
+	End       time.Time             `json:"end"`
+	Phase     gopomodoro.CycleState `json:"phase"`
+	Pomodoro  int                   `json:"pomodoro,omitempty"`
+	Planned   textduration.Duration `json:"planned"`
+	Actual    textduration.Duration `json:"actual"`
+	Extension textduration.Duration `json:"extension,omitempty"`
+	Paused    textduration.Duration `json:"paused,omitempty"`
+	Outcome   Outcome               `json:"outcome"`
+	Reason    string                `json:"reason,omitempty"`
+	Internal  int                   `json:"internal_interruptions,omitempty"`
+	External  int                   `json:"external_interruptions,omitempty"`
+	Elapsed   textduration.Duration `json:"elapsed,omitempty"`
+	Task      string                `json:"task,omitempty"`
+	Tags      []string              `json:"tags,omitempty"`
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(line{
+		Start:     r.Start,
+		End:       r.End,
+		Phase:     r.Phase,
+		Pomodoro:  r.Pomodoro,
+		Planned:   textduration.Duration(r.Planned),
+		Actual:    textduration.Duration(r.Actual),
+		Extension: textduration.Duration(r.Extension),
+		Paused:    textduration.Duration(r.Paused),
+		Outcome:   r.Outcome,
+		Reason:    r.Reason,
+		Internal:  r.Interruptions.Internal,
+		External:  r.Interruptions.External,
+		Elapsed:   textduration.Duration(r.Elapsed),
+		Task:      r.Task.Name,
+		Tags:      r.Task.Tags,
+	})
+}
+
+func (r *Record) UnmarshalJSON(data []byte) error {
+	var l line
+	if err := json.Unmarshal(data, &l); err != nil {
+		return err
+	}
+	*r = Record{
+		Start:         l.Start,
+		End:           l.End,
+		Phase:         l.Phase,
+		Pomodoro:      l.Pomodoro,
+		Planned:       time.Duration(l.Planned),
+		Actual:        time.Duration(l.Actual),
+		Extension:     time.Duration(l.Extension),
+		Paused:        time.Duration(l.Paused),
+		Outcome:       l.Outcome,
+		Reason:        l.Reason,
+		Interruptions: gopomodoro.Interruptions{Internal: l.Internal, External: l.External},
+		Elapsed:       time.Duration(l.Elapsed),
+		Task:          gopomodoro.Task{Name: l.Task, Tags: l.Tags},
+	}
+	return nil
+}
+
+// Recorder appends a Record to Log whenever a phase ends. Register it with
+// Cycle.Subscribe.
+type Recorder struct {
+	Log *Log
+
+	// OnError is called when a record cannot be written. Optional.
+	OnError func(error)
+}
+
+func (r *Recorder) OnEvent(e gopomodoro.Event) {
+	record, ok := FromEvent(e)
+	if !ok {
+		return
+	}
+	if err := r.Log.Append(record); err != nil && r.OnError != nil {
+		r.OnError(err)
+	}
+}
+
+var _ gopomodoro.EventSubscriber = (*Recorder)(nil)
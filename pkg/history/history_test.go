@@ -0,0 +1,117 @@
+package history_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/history"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+var start = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+func TestRecorder_GivenPomodoroCompletes_WhenRecorded_ThenLogHasCompletedRecord(t *testing.T) {
+	clock := pomotest.NewMockClock(start)
+	log := &history.Log{Path: filepath.Join(t.TempDir(), history.FileName)}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock}
+	c.Subscribe(&history.Recorder{Log: log})
+	c.Start()
+	c.Interrupt(gopomodoro.InternalInterruption)
+	clock.Advance(10 * time.Minute)
+	c.Pause()
+	clock.Advance(4 * time.Minute)
+	c.Resume()
+	_ = c.Extend(time.Minute)
+
+	clock.Advance(16 * time.Minute)
+	c.Tick()
+
+	records, err := log.Query(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := history.Record{
+		Start:         start,
+		End:           start.Add(30 * time.Minute),
+		Phase:         gopomodoro.Pomodoro,
+		Pomodoro:      1,
+		Planned:       25 * time.Minute,
+		Actual:        26 * time.Minute,
+		Extension:     time.Minute,
+		Paused:        4 * time.Minute,
+		Outcome:       history.Completed,
+		Interruptions: gopomodoro.Interruptions{Internal: 1},
+	}
+	if len(records) != 1 || !reflect.DeepEqual(records[0], expected) {
+		t.Fatalf("expected [%+v], got %+v", expected, records)
+	}
+}
+
+func TestRecorder_GivenRunningPomodoro_WhenStopped_ThenLogHasVoidedRecord(t *testing.T) {
+	clock := pomotest.NewMockClock(start)
+	log := &history.Log{Path: filepath.Join(t.TempDir(), history.FileName)}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock}
+	c.Subscribe(&history.Recorder{Log: log})
+	c.Start()
+	clock.Advance(12 * time.Minute)
+
+	c.Void("meeting")
+
+	records, _ := log.Query(time.Time{}, time.Time{})
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.Outcome != history.Voided || r.Reason != "meeting" || r.Actual != 12*time.Minute || r.Elapsed != 12*time.Minute {
+		t.Fatalf("expected voided pomodoro after 12m for meeting, got %+v", r)
+	}
+}
+
+func TestFromEvent_GivenSkippedBreak_WhenConverted_ThenOutcomeIsSkipped(t *testing.T) {
+	e := gopomodoro.Event{
+		Type:      gopomodoro.PhaseCompleted,
+		Time:      start.Add(2 * time.Minute),
+		Started:   start,
+		Phase:     gopomodoro.ShortBreak,
+		Planned:   5 * time.Minute,
+		Remaining: 3 * time.Minute,
+		Skipped:   true,
+	}
+
+	r, ok := history.FromEvent(e)
+
+	if !ok || r.Outcome != history.Skipped || r.Actual != 2*time.Minute {
+		t.Fatalf("expected skipped break of 2m, got %+v", r)
+	}
+}
+
+func TestFromEvent_GivenTick_WhenConverted_ThenNoRecord(t *testing.T) {
+	if _, ok := history.FromEvent(gopomodoro.Event{Type: gopomodoro.Ticked}); ok {
+		t.Fatal("expected no record for a tick")
+	}
+}
+
+func TestRecord_GivenRecord_WhenMarshalled_ThenDurationsAreReadable(t *testing.T) {
+	r := history.Record{
+		Start:   start,
+		End:     start.Add(25 * time.Minute),
+		Phase:   gopomodoro.Pomodoro,
+		Planned: 25 * time.Minute,
+		Actual:  25 * time.Minute,
+		Outcome: history.Completed,
+	}
+
+	data, err := json.Marshal(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := `{"start":"2026-01-05T09:00:00Z","end":"2026-01-05T09:25:00Z","phase":"Pomodoro","planned":"25m0s","actual":"25m0s","outcome":"completed"}`
+	if string(data) != expected {
+		t.Fatalf("expected %s, got %s", expected, data)
+	}
+}
@@ -0,0 +1,162 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FileName is the name of the history log inside the data directory.
+const FileName = "history.jsonl"
+
+const (
+	// DefaultMaxSize keeps several months of records in the active file.
+	DefaultMaxSize = 1 << 20
+	// DefaultMaxBackups is the number of rotated files kept.
+	DefaultMaxBackups = 5
+)
+
+// Log is an append-only JSON Lines file with one Record per line. Before a
+// write would grow the file beyond MaxSize, it is rotated to Path.1, older
+// backups move up to Path.MaxBackups, and the oldest is removed. Zero limits
+// use DefaultMaxSize and DefaultMaxBackups.
+type Log struct {
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+
+	mu sync.Mutex
+}
+
+// Append writes r as the last line of the log.
+func (l *Log) Append(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encode history record: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return fmt.Errorf("create history directory: %w", err)
+	}
+	if err := l.rotateFor(int64(len(data))); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("append history: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close history: %w", err)
+	}
+	return nil
+}
+
+// Query returns the records of phases that started in [from, to), oldest
+// first, across the active file and its backups. A zero from or to leaves
+// that end of the range open. Lines that cannot be decoded, such as one cut
+// short by a crash, are skipped.
+func (l *Log) Query(from, to time.Time) ([]Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var records []Record
+	for i := l.maxBackups(); i >= 0; i-- {
+		rs, err := readFile(l.file(i))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rs {
+			if !from.IsZero() && r.Start.Before(from) {
+				continue
+			}
+			if !to.IsZero() && !r.Start.Before(to) {
+				continue
+			}
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// file returns the path of backup n, or of the active file for n == 0.
+func (l *Log) file(n int) string {
+	if n == 0 {
+		return l.Path
+	}
+	return l.Path + "." + strconv.Itoa(n)
+}
+
+func (l *Log) maxSize() int64 {
+	if l.MaxSize <= 0 {
+		return DefaultMaxSize
+	}
+	return l.MaxSize
+}
+
+func (l *Log) maxBackups() int {
+	if l.MaxBackups <= 0 {
+		return DefaultMaxBackups
+	}
+	return l.MaxBackups
+}
+
+// rotateFor rotates the active file if writing n more bytes would exceed
+// the maximum size. A file is never rotated while empty.
+func (l *Log) rotateFor(n int64) error {
+	info, err := os.Stat(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat history: %w", err)
+	}
+	if info.Size() == 0 || info.Size()+n <= l.maxSize() {
+		return nil
+	}
+	if err := os.Remove(l.file(l.maxBackups())); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("rotate history: %w", err)
+	}
+	for i := l.maxBackups(); i > 0; i-- {
+		if err := os.Rename(l.file(i-1), l.file(i)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate history: %w", err)
+		}
+	}
+	return nil
+}
+
+func readFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history %s: %w", path, err)
+	}
+	return records, nil
+}
@@ -0,0 +1,89 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+func pomodoroAt(t time.Time) history.Record {
+	return history.Record{
+		Start:   t,
+		End:     t.Add(25 * time.Minute),
+		Phase:   gopomodoro.Pomodoro,
+		Planned: 25 * time.Minute,
+		Actual:  25 * time.Minute,
+		Outcome: history.Completed,
+	}
+}
+
+func TestLog_GivenRecordsOnSeveralDays_WhenQueriedForOneDay_ThenOnlyThatDayIsReturned(t *testing.T) {
+	log := &history.Log{Path: filepath.Join(t.TempDir(), history.FileName)}
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{day.Add(-time.Hour), day.Add(9 * time.Hour), day.Add(23 * time.Hour), day.Add(24 * time.Hour)} {
+		if err := log.Append(pomodoroAt(at)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	records, err := log.Query(day, day.Add(24*time.Hour))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(records) != 2 || !records[0].Start.Equal(day.Add(9*time.Hour)) {
+		t.Fatalf("expected the 2 records of the day in order, got %+v", records)
+	}
+}
+
+func TestLog_GivenNoFile_WhenQueried_ThenEmpty(t *testing.T) {
+	log := &history.Log{Path: filepath.Join(t.TempDir(), history.FileName)}
+
+	records, err := log.Query(time.Time{}, time.Time{})
+
+	if err != nil || len(records) != 0 {
+		t.Fatalf("expected no records and no error, got %v, %v", records, err)
+	}
+}
+
+func TestLog_GivenTruncatedLine_WhenQueried_ThenItIsSkipped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), history.FileName)
+	log := &history.Log{Path: path}
+	_ = log.Append(pomodoroAt(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)))
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	_, _ = f.WriteString(`{"start":"2026-01-05T10:00`)
+	f.Close()
+
+	records, err := log.Query(time.Time{}, time.Time{})
+
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected 1 record and no error, got %v, %v", records, err)
+	}
+}
+
+func TestLog_GivenSmallMaxSize_WhenAppending_ThenRotatesAndKeepsMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	log := &history.Log{Path: filepath.Join(dir, history.FileName), MaxSize: 100, MaxBackups: 2}
+	first := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		if err := log.Append(pomodoroAt(first.Add(time.Duration(i) * time.Hour))); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 3 {
+		t.Fatalf("expected active file and 2 backups, got %v", entries)
+	}
+	records, err := log.Query(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(records) != 3 || !records[0].Start.Equal(first.Add(2*time.Hour)) || !records[2].Start.Equal(first.Add(4*time.Hour)) {
+		t.Fatalf("expected the 3 newest records oldest first, got %+v", records)
+	}
+}
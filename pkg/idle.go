@@ -0,0 +1,54 @@
+package gopomodoro
+
+import "time"
+
+// PauseIdle pauses the running pomodoro because the user has been idle
+// since since. The idle time before the pause still counts towards the
+// pomodoro unless it is given back through DiscardIdle. It has no effect
+// outside a running pomodoro.
+func (c *Cycle) PauseIdle(since time.Time) {
+	c.mu.Lock()
+	c.pauseIdle(since)
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) pauseIdle(since time.Time) {
+	if c.State != Pomodoro || c.paused || c.waiting {
+		return
+	}
+	c.pause()
+	if since.Before(c.started) {
+		since = c.started
+	}
+	c.idle = max(0, c.pausedAt.Sub(since))
+	c.notifyStateChanged()
+}
+
+// DiscardIdle gives the idle time before the last PauseIdle back to the
+// pomodoro: it is counted as paused and added to the time left. It has no
+// effect once the pomodoro has ended or the idle time was discarded already.
+func (c *Cycle) DiscardIdle() {
+	c.mu.Lock()
+	c.discardIdle()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) discardIdle() {
+	if c.idle <= 0 {
+		return
+	}
+	idle := c.idle
+	c.idle = 0
+	if c.paused {
+		c.TimeLeft += idle
+		c.pausedAt = c.pausedAt.Add(-idle)
+	} else {
+		c.deadline = c.deadline.Add(idle)
+		c.pausedFor += idle
+		c.TimeLeft = c.remaining()
+	}
+	c.emit(IdleDiscarded)
+	c.notifyStateChanged()
+}
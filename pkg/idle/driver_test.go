@@ -0,0 +1,145 @@
+package idle_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/idle"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func drivenCycle(action idle.Action) (*gopomodoro.Cycle, *idle.Driver, *pomotest.MockIdleSource, *pomotest.MockClock) {
+	c, clock, _ := pomotest.NewCycle(nil)
+	source := &pomotest.MockIdleSource{}
+	c.Start()
+	return c, &idle.Driver{Cycle: c, Source: source, Action: action, Clock: clock}, source, clock
+}
+
+func TestDriver_GivenIdleBelowThreshold_WhenChecked_ThenKeepsRunning(t *testing.T) {
+	c, d, source, clock := drivenCycle(idle.Pause)
+	clock.Advance(10 * time.Minute)
+	source.SetIdle(pomotest.StartTime.Add(6 * time.Minute))
+
+	d.Check()
+
+	if c.IsPaused() {
+		t.Fatal("expected the pomodoro to keep running after 4m idle")
+	}
+}
+
+func TestDriver_GivenIdleForThreshold_WhenChecked_ThenPausesOnce(t *testing.T) {
+	c, d, source, clock := drivenCycle(idle.Pause)
+	clock.Advance(10 * time.Minute)
+	source.SetIdle(pomotest.StartTime.Add(5 * time.Minute))
+
+	d.Check()
+
+	s := c.Snapshot()
+	if !s.Paused || s.IdleTime != 5*time.Minute {
+		t.Fatalf("expected a pause offering 5m idle, got %+v", s)
+	}
+
+	c.Resume()
+	clock.Advance(time.Minute)
+	d.Check()
+	if c.IsPaused() {
+		t.Fatal("expected a pomodoro resumed by hand not to be paused again for the same idle stretch")
+	}
+}
+
+func TestDriver_GivenVoidAction_WhenIdleForThreshold_ThenVoids(t *testing.T) {
+	c, d, source, clock := drivenCycle(idle.Void)
+	d.Threshold = 2 * time.Minute
+	subscriber := &pomotest.MockSubscriber{}
+	c.Subscribe(subscriber)
+	clock.Advance(10 * time.Minute)
+	source.SetIdle(pomotest.StartTime.Add(7 * time.Minute))
+
+	d.Check()
+
+	stopped, ok := subscriber.Last(gopomodoro.Stopped)
+	if !ok || !stopped.Voided || stopped.Reason != "idle since 09:07" {
+		t.Fatalf("expected the pomodoro voided for idleness, got %+v", stopped)
+	}
+}
+
+func TestDriver_GivenStrictCycle_WhenIdleForThreshold_ThenVoids(t *testing.T) {
+	c, clock, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) { c.Strict = true })
+	source := &pomotest.MockIdleSource{}
+	d := &idle.Driver{Cycle: c, Source: source, Clock: clock}
+	c.Start()
+	clock.Advance(10 * time.Minute)
+	source.SetIdle(pomotest.StartTime.Add(5 * time.Minute))
+
+	d.Check()
+
+	stopped, ok := subscriber.Last(gopomodoro.Stopped)
+	if !ok || !stopped.Voided || stopped.Reason != "idle since 09:05" || stopped.Elapsed != 10*time.Minute {
+		t.Fatalf("expected the pomodoro voided with the time it ran, got %+v", stopped)
+	}
+}
+
+func TestDriver_GivenBreak_WhenIdleForThreshold_ThenLeavesItRunning(t *testing.T) {
+	c, d, source, clock := drivenCycle(idle.Pause)
+	c.Skip()
+	clock.Advance(10 * time.Minute)
+	source.SetIdle(pomotest.StartTime)
+
+	d.Check()
+
+	if c.IsPaused() {
+		t.Fatal("expected the break to keep running")
+	}
+}
+
+func TestDriver_GivenFailingSource_WhenCheckedTwice_ThenReportsOnce(t *testing.T) {
+	_, d, source, clock := drivenCycle(idle.Pause)
+	var errs []error
+	d.OnError = func(err error) { errs = append(errs, err) }
+	source.SetError(errors.New("no session"))
+
+	d.Check()
+	clock.Advance(idle.DefaultInterval)
+	d.Check()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", errs)
+	}
+}
+
+func TestDriver_GivenNoRunningPomodoro_WhenChecked_ThenDoesNotAskSource(t *testing.T) {
+	c, d, source, _ := drivenCycle(idle.Pause)
+	c.Stop()
+
+	d.Check()
+
+	if source.Calls() != 0 {
+		t.Fatalf("expected the source not to be asked while idle, got %d calls", source.Calls())
+	}
+}
+
+func TestDriver_GivenRunningPomodoro_WhenCheckedEverySecond_ThenAsksSourceOncePerInterval(t *testing.T) {
+	_, d, source, clock := drivenCycle(idle.Pause)
+
+	for i := 0; i < 30; i++ {
+		d.Check()
+		clock.Advance(time.Second)
+	}
+
+	if source.Calls() != 2 {
+		t.Fatalf("expected the source asked twice in 30s, got %d calls", source.Calls())
+	}
+}
+
+func TestParseAction_GivenName_WhenParsed_ThenRoundTrips(t *testing.T) {
+	for _, a := range []idle.Action{idle.Pause, idle.Void} {
+		if parsed, err := idle.ParseAction(a.String()); err != nil || parsed != a {
+			t.Fatalf("expected %v, got %v, %v", a, parsed, err)
+		}
+	}
+	if _, err := idle.ParseAction("sleep"); err == nil {
+		t.Fatal("expected an unknown action to fail")
+	}
+}
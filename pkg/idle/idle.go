@@ -0,0 +1,140 @@
+// Package idle pauses or voids a pomodoro nobody is working on, so a
+// walk away from the desk is not recorded as focus.
+package idle
+
+import (
+	"fmt"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/internal/poll"
+)
+
+// DefaultThreshold is how long the user may be idle before a pomodoro is
+// paused or voided.
+const DefaultThreshold = 5 * time.Minute
+
+// DefaultInterval is how often the Source is asked while a pomodoro runs.
+// Idleness is measured in minutes, so there is no need to ask every second.
+const DefaultInterval = 15 * time.Second
+
+// Source tells whether the user is idle.
+type Source interface {
+	// IdleSince returns when the user went idle, or the zero time while
+	// they are active.
+	IdleSince() (time.Time, error)
+}
+
+// Action is what Driver does to a pomodoro once the user has been idle for
+// the threshold.
+type Action int
+
+const (
+	// Pause pauses the pomodoro and offers to discard the idle time.
+	Pause Action = iota
+	// Void stops the cycle, voiding the pomodoro.
+	Void
+)
+
+var actionNames = []string{"pause", "void"}
+
+func (a Action) String() string {
+	if a >= 0 && int(a) < len(actionNames) {
+		return actionNames[a]
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// ParseAction returns the action called name.
+func ParseAction(name string) (Action, error) {
+	for i, n := range actionNames {
+		if n == name {
+			return Action(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown idle action %q", name)
+}
+
+// Driver pauses or voids the running pomodoro once the Source has reported
+// the user idle for Threshold; a strict cycle's pomodoro is always voided.
+// Breaks are left alone.
+type Driver struct {
+	Cycle  *gopomodoro.Cycle
+	Source Source
+	Action Action
+
+	// Threshold is how long the user may be idle; zero uses
+	// DefaultThreshold.
+	Threshold time.Duration
+
+	// Interval is how often the Source is asked while a pomodoro runs; zero
+	// uses DefaultInterval.
+	Interval time.Duration
+
+	// Clock is optional; the system clock is used when nil.
+	Clock gopomodoro.Clock
+
+	// Ticker paces Run.
+	Ticker gopomodoro.Ticker
+
+	// OnError is optional; it receives errors reading the Source, each one
+	// once until the Source can be read again.
+	OnError func(error)
+
+	// handled is the start of the idle stretch acted on last, so a
+	// pomodoro resumed by hand is not paused again for it; polled is when
+	// the Source was asked last.
+	handled time.Time
+	polled  time.Time
+	errors  poll.Errors
+}
+
+// Run checks the Source on every tick of the Ticker. It blocks forever.
+func (d *Driver) Run() {
+	poll.Run(d.Ticker, d.Check)
+}
+
+// Check acts on a running pomodoro if the user has been idle for the
+// threshold. The Source is only asked while a pomodoro runs, at most once
+// per Interval.
+func (d *Driver) Check() {
+	s := d.Cycle.Snapshot()
+	if s.State != gopomodoro.Pomodoro || s.Paused || s.Waiting {
+		d.polled = time.Time{}
+		return
+	}
+	now := poll.Now(d.Clock)
+	if !d.polled.IsZero() && now.Sub(d.polled) < d.interval() {
+		return
+	}
+	d.polled = now
+
+	since, err := d.Source.IdleSince()
+	if d.errors.Report(err, d.OnError) {
+		return
+	}
+	if since.IsZero() || since.Equal(d.handled) || now.Sub(since) < d.threshold() {
+		return
+	}
+	d.handled = since
+	// A pomodoro cannot be paused in strict mode.
+	if d.Action == Void || d.Cycle.Strict {
+		d.Cycle.Void("idle since " + since.Format("15:04"))
+	} else {
+		d.Cycle.PauseIdle(since)
+	}
+}
+
+func (d *Driver) interval() time.Duration {
+	if d.Interval > 0 {
+		return d.Interval
+	}
+	return DefaultInterval
+}
+
+func (d *Driver) threshold() time.Duration {
+	if d.Threshold > 0 {
+		return d.Threshold
+	}
+	return DefaultThreshold
+}
@@ -0,0 +1,74 @@
+package idle
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Logind reads the idle hint of the desktop session from systemd-logind
+// over the system D-Bus, through busctl. Desktops such as GNOME and KDE set
+// the hint once their own idle delay has passed, so the idle time it reports
+// starts then.
+type Logind struct {
+	// Session is the D-Bus object path of the session to watch; empty
+	// watches the session gopomodoro runs in.
+	Session string
+}
+
+// IdleSince implements Source using the IdleHint and IdleSinceHint
+// properties of the session.
+func (l Logind) IdleSince() (time.Time, error) {
+	session := l.Session
+	if session == "" {
+		session = "/org/freedesktop/login1/session/auto"
+	}
+	out, err := exec.Command("busctl", "--system", "get-property",
+		"org.freedesktop.login1", session, "org.freedesktop.login1.Session",
+		"IdleHint", "IdleSinceHint").Output()
+	if err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && len(exit.Stderr) > 0 {
+			err = errors.New(strings.TrimSpace(string(exit.Stderr)))
+		}
+		return time.Time{}, fmt.Errorf("read logind idle hint: %w", err)
+	}
+	return ParseIdleHint(out)
+}
+
+// ParseIdleHint parses the busctl output for IdleHint and IdleSinceHint,
+// such as "b true\nt 1767603600000000\n". IdleSinceHint counts microseconds
+// since the Unix epoch.
+func ParseIdleHint(out []byte) (time.Time, error) {
+	var values []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			values = append(values, line)
+		}
+	}
+	if len(values) != 2 {
+		return time.Time{}, fmt.Errorf("parse logind idle hint: unexpected output %q", out)
+	}
+
+	idle, ok := strings.CutPrefix(values[0], "b ")
+	if !ok || idle != "true" && idle != "false" {
+		return time.Time{}, fmt.Errorf("parse logind idle hint: unexpected IdleHint %q", values[0])
+	}
+	since, ok := strings.CutPrefix(values[1], "t ")
+	micros, err := strconv.ParseInt(since, 10, 64)
+	if !ok || err != nil {
+		return time.Time{}, fmt.Errorf("parse logind idle hint: unexpected IdleSinceHint %q", values[1])
+	}
+	if idle == "false" || micros == 0 {
+		return time.Time{}, nil
+	}
+	return time.UnixMicro(micros), nil
+}
+
+var _ Source = Logind{}
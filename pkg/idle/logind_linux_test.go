@@ -0,0 +1,32 @@
+package idle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/idle"
+)
+
+func TestParseIdleHint_GivenIdleSession_WhenParsed_ThenReturnsSince(t *testing.T) {
+	since, err := idle.ParseIdleHint([]byte("b true\nt 1767603600000000\n"))
+
+	if err != nil || !since.Equal(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected 2026-01-05 09:00 UTC, got %v, %v", since, err)
+	}
+}
+
+func TestParseIdleHint_GivenActiveSession_WhenParsed_ThenReturnsZero(t *testing.T) {
+	since, err := idle.ParseIdleHint([]byte("b false\nt 1767603600000000\n"))
+
+	if err != nil || !since.IsZero() {
+		t.Fatalf("expected the zero time, got %v, %v", since, err)
+	}
+}
+
+func TestParseIdleHint_GivenUnexpectedOutput_WhenParsed_ThenFails(t *testing.T) {
+	for _, out := range []string{"", "b true\n", "s \"yes\"\nt 0\n", "b true\nt soon\n"} {
+		if _, err := idle.ParseIdleHint([]byte(out)); err == nil {
+			t.Fatalf("expected %q to fail", out)
+		}
+	}
+}
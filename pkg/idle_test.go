@@ -0,0 +1,90 @@
+package gopomodoro_test
+
+import (
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+// idleCycle returns a pomodoro that ran for 15 minutes, the last 5 of them
+// idle, and was then paused through PauseIdle.
+func idleCycle() (*gopomodoro.Cycle, *pomotest.MockClock, *pomotest.MockSubscriber) {
+	c, clock, subscriber := pomotest.NewCycle(nil)
+	c.Start()
+	clock.Advance(15 * time.Minute)
+	c.PauseIdle(pomotest.StartTime.Add(10 * time.Minute))
+	return c, clock, subscriber
+}
+
+func TestIdle_GivenRunningPomodoro_WhenPausedIdle_ThenOffersIdleTime(t *testing.T) {
+	c, _, _ := idleCycle()
+
+	s := c.Snapshot()
+	if !s.Paused || s.Remaining != 10*time.Minute || s.IdleTime != 5*time.Minute {
+		t.Fatalf("expected a paused pomodoro offering 5m idle, got %+v", s)
+	}
+}
+
+func TestIdle_GivenPausedIdle_WhenDiscarded_ThenIdleTimeIsGivenBack(t *testing.T) {
+	c, clock, subscriber := idleCycle()
+	clock.Advance(20 * time.Minute)
+
+	c.DiscardIdle()
+
+	s := c.Snapshot()
+	if s.Remaining != 15*time.Minute || s.IdleTime != 0 {
+		t.Fatalf("expected 15m left and nothing more to discard, got %+v", s)
+	}
+	discarded, ok := subscriber.Last(gopomodoro.IdleDiscarded)
+	if !ok || discarded.PausedFor != 25*time.Minute {
+		t.Fatalf("expected the idle time counted as paused, got %+v", discarded)
+	}
+}
+
+func TestIdle_GivenResumedAfterIdle_WhenDiscarded_ThenDeadlineMoves(t *testing.T) {
+	c, clock, _ := idleCycle()
+	clock.Advance(20 * time.Minute)
+	c.Resume()
+	clock.Advance(time.Minute)
+
+	c.DiscardIdle()
+	c.DiscardIdle()
+
+	if remaining := c.Remaining(); remaining != 14*time.Minute {
+		t.Fatalf("expected 14m left, got %v", remaining)
+	}
+}
+
+func TestIdle_GivenIdleSinceBeforePomodoro_WhenPausedIdle_ThenOnlyCountsThePomodoro(t *testing.T) {
+	c, clock, _ := pomotest.NewCycle(nil)
+	c.Start()
+	clock.Advance(3 * time.Minute)
+
+	c.PauseIdle(pomotest.StartTime.Add(-time.Hour))
+
+	if idle := c.Snapshot().IdleTime; idle != 3*time.Minute {
+		t.Fatalf("expected 3m idle, got %v", idle)
+	}
+}
+
+func TestIdle_GivenBreak_WhenPausedIdle_ThenNothingHappens(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, State: gopomodoro.ShortBreak, TimeLeft: 5 * time.Minute}
+
+	c.PauseIdle(time.Now().Add(-10 * time.Minute))
+
+	if c.IsPaused() {
+		t.Fatal("expected a break not to be paused for idleness")
+	}
+}
+
+func TestIdle_GivenPausedIdle_WhenStopped_ThenNothingToDiscard(t *testing.T) {
+	c, _, _ := idleCycle()
+
+	c.Stop()
+
+	if idle := c.Snapshot().IdleTime; idle != 0 {
+		t.Fatalf("expected no idle time after stopping, got %v", idle)
+	}
+}
@@ -0,0 +1,48 @@
+// Package poll holds what the drivers that act on a cycle from outside, such
+// as the work hours, calendar and idle drivers, share: running their check
+// on every tick, reading the clock and reporting errors.
+package poll
+
+import (
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// Run starts ticker and calls check on every tick. It blocks forever.
+func Run(ticker gopomodoro.Ticker, check func()) {
+	ticker.Start()
+	for range ticker.OnTick() {
+		check()
+	}
+}
+
+// Now returns the time from clock, or the system time when clock is nil.
+func Now(clock gopomodoro.Clock) time.Time {
+	if clock != nil {
+		return clock.Now()
+	}
+	return time.Now()
+}
+
+// Errors reports each error a check runs into once, until a check succeeds
+// or fails differently.
+type Errors struct {
+	// failing is the error reported last.
+	failing string
+}
+
+// Report passes err to onError, if set, unless it was reported last, and
+// tells whether there was an error. A nil err clears the error reported
+// last.
+func (e *Errors) Report(err error, onError func(error)) bool {
+	if err == nil {
+		e.failing = ""
+		return false
+	}
+	if onError != nil && err.Error() != e.failing {
+		onError(err)
+	}
+	e.failing = err.Error()
+	return true
+}
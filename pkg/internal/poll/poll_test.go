@@ -0,0 +1,69 @@
+package poll_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/internal/poll"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestRun_GivenTicker_WhenTicked_ThenChecks(t *testing.T) {
+	ticker := pomotest.NewMockTicker()
+	checked := make(chan struct{})
+	go poll.Run(ticker, func() { checked <- struct{}{} })
+
+	ticker.Fire()
+
+	select {
+	case <-checked:
+	case <-time.After(time.Second):
+		t.Fatal("expected a check on the tick")
+	}
+}
+
+func TestNow_GivenClock_WhenRead_ThenUsesIt(t *testing.T) {
+	clock := pomotest.NewMockClock(pomotest.StartTime)
+
+	if now := poll.Now(clock); !now.Equal(pomotest.StartTime) {
+		t.Fatalf("expected %v, got %v", pomotest.StartTime, now)
+	}
+}
+
+func TestNow_GivenNoClock_WhenRead_ThenUsesSystemTime(t *testing.T) {
+	before := time.Now()
+
+	now := poll.Now(nil)
+
+	if now.Before(before) || now.After(time.Now()) {
+		t.Fatalf("expected the system time, got %v", now)
+	}
+}
+
+func TestErrors_GivenSameErrorTwice_WhenReported_ThenPassedOnOnce(t *testing.T) {
+	var errs poll.Errors
+	var reported []error
+	onError := func(err error) { reported = append(reported, err) }
+
+	errs.Report(errors.New("unreadable"), onError)
+	errs.Report(errors.New("unreadable"), onError)
+
+	if len(reported) != 1 {
+		t.Fatalf("expected the error reported once, got %v", reported)
+	}
+}
+
+func TestErrors_GivenRecovery_WhenSameErrorAgain_ThenPassedOnAgain(t *testing.T) {
+	var errs poll.Errors
+	var reported []error
+	onError := func(err error) { reported = append(reported, err) }
+	errs.Report(errors.New("unreadable"), onError)
+
+	failed := errs.Report(nil, onError)
+	errs.Report(errors.New("unreadable"), onError)
+
+	if failed || len(reported) != 2 {
+		t.Fatalf("expected the error reported again after recovering, got %v", reported)
+	}
+}
@@ -0,0 +1,21 @@
+// Package textduration writes and reads durations in the files gopomodoro
+// keeps as Go duration strings such as "25m0s".
+package textduration
+
+import "time"
+
+// Duration is a time.Duration marshalled as text.
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
@@ -0,0 +1,35 @@
+package textduration_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/internal/textduration"
+)
+
+func TestDuration_GivenDuration_WhenMarshalled_ThenWrittenAsGoDuration(t *testing.T) {
+	data, err := json.Marshal(textduration.Duration(25 * time.Minute))
+
+	if err != nil || string(data) != `"25m0s"` {
+		t.Fatalf(`expected "25m0s", got %s (%v)`, data, err)
+	}
+}
+
+func TestDuration_GivenGoDuration_WhenUnmarshalled_ThenParsed(t *testing.T) {
+	var d textduration.Duration
+
+	err := json.Unmarshal([]byte(`"1h30m"`), &d)
+
+	if err != nil || time.Duration(d) != 90*time.Minute {
+		t.Fatalf("expected 1h30m, got %v (%v)", time.Duration(d), err)
+	}
+}
+
+func TestDuration_GivenInvalidText_WhenUnmarshalled_ThenError(t *testing.T) {
+	var d textduration.Duration
+
+	if err := json.Unmarshal([]byte(`"soon"`), &d); err == nil {
+		t.Fatal("expected an error")
+	}
+}
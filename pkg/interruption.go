@@ -0,0 +1,68 @@
+package gopomodoro
+
+// InterruptionKind tells interruptions you caused yourself apart from those
+// caused by others, following Cirillo's internal/external distinction.
+type InterruptionKind int
+
+const (
+	InternalInterruption InterruptionKind = iota
+	ExternalInterruption
+)
+
+func (k InterruptionKind) String() string {
+	if k == ExternalInterruption {
+		return "external"
+	}
+	return "internal"
+}
+
+// Interruptions counts the interruptions recorded against a pomodoro.
+type Interruptions struct {
+	Internal int
+	External int
+}
+
+// Total returns the number of interruptions of either kind.
+func (i Interruptions) Total() int {
+	return i.Internal + i.External
+}
+
+// DefaultVoidReason is recorded when a pomodoro is voided through Stop.
+const DefaultVoidReason = "stopped"
+
+// Interrupt records an interruption against the running pomodoro. The
+// pomodoro keeps running; use Void to abandon it. It has no effect outside a
+// started pomodoro.
+func (c *Cycle) Interrupt(kind InterruptionKind) {
+	c.mu.Lock()
+	c.interrupt(kind)
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) interrupt(kind InterruptionKind) {
+	if c.State != Pomodoro || c.waiting {
+		return
+	}
+	if kind == ExternalInterruption {
+		c.interruptions.External++
+	} else {
+		c.interruptions.Internal++
+	}
+	interrupted := c.event(Interrupted)
+	interrupted.Interruption = kind
+	c.publish(interrupted)
+	c.notifyStateChanged()
+}
+
+// Void abandons the running pomodoro, recording it as voided for reason, and
+// returns the cycle to Idle. Outside a pomodoro it behaves like Stop.
+func (c *Cycle) Void(reason string) {
+	if reason == "" {
+		reason = DefaultVoidReason
+	}
+	c.mu.Lock()
+	c.stop(reason)
+	c.mu.Unlock()
+	c.deliver()
+}
@@ -0,0 +1,129 @@
+package gopomodoro_test
+
+import (
+	"testing"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestInterruption_GivenRunningPomodoro_WhenInterrupted_ThenCountsByKind(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+
+	c.Interrupt(gopomodoro.InternalInterruption)
+	c.Interrupt(gopomodoro.ExternalInterruption)
+	c.Interrupt(gopomodoro.ExternalInterruption)
+
+	expected := gopomodoro.Interruptions{Internal: 1, External: 2}
+	snapshot := c.Snapshot()
+	if snapshot.Interruptions != expected {
+		t.Fatalf("expected %+v, got %+v", expected, snapshot.Interruptions)
+	}
+	if snapshot.State != gopomodoro.Pomodoro {
+		t.Fatalf("expected pomodoro to keep running, got %v", snapshot.State)
+	}
+}
+
+func TestInterruption_GivenShortBreak_WhenInterrupted_ThenNothingIsRecorded(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	c.Interrupt(gopomodoro.ExternalInterruption)
+
+	if c.Snapshot().Interruptions.Total() != 0 {
+		t.Fatalf("expected no interruptions, got %+v", c.Snapshot().Interruptions)
+	}
+	if _, ok := subscriber.Last(gopomodoro.Interrupted); ok {
+		t.Fatal("expected no Interrupted event")
+	}
+}
+
+func TestInterruption_GivenInterruptedPomodoro_WhenCompleted_ThenEventCarriesCountsAndNextPomodoroStartsClean(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+	c.Interrupt(gopomodoro.InternalInterruption)
+
+	pomotest.CompleteCycle(c)
+	pomotest.CompleteCycle(c)
+
+	completed, _ := subscriber.First(gopomodoro.PhaseCompleted)
+	if completed.Phase != gopomodoro.Pomodoro || completed.Interruptions.Internal != 1 || completed.Voided {
+		t.Fatalf("expected completed pomodoro with 1 internal interruption, got %+v", completed)
+	}
+	if c.Snapshot().Interruptions.Total() != 0 {
+		t.Fatalf("expected next pomodoro to start without interruptions, got %+v", c.Snapshot().Interruptions)
+	}
+}
+
+func TestInterruption_GivenInterruptedPomodoro_WhenInterrupted_ThenEmitsKind(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	c.Interrupt(gopomodoro.ExternalInterruption)
+
+	interrupted, ok := subscriber.Last(gopomodoro.Interrupted)
+	if !ok {
+		t.Fatal("expected an Interrupted event")
+	}
+	if interrupted.Interruption != gopomodoro.ExternalInterruption || interrupted.Interruptions.External != 1 {
+		t.Fatalf("expected external interruption, got %+v", interrupted)
+	}
+}
+
+func TestVoid_GivenRunningPomodoro_WhenVoided_ThenStoppedEventIsVoidedWithReason(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+	c.Interrupt(gopomodoro.ExternalInterruption)
+
+	c.Void("phone call")
+
+	stopped, ok := subscriber.Last(gopomodoro.Stopped)
+	if !ok {
+		t.Fatal("expected a Stopped event")
+	}
+	if !stopped.Voided || stopped.Reason != "phone call" || stopped.Interruptions.External != 1 {
+		t.Fatalf("expected voided pomodoro for phone call, got %+v", stopped)
+	}
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected Idle, got %v", c.Snapshot().State)
+	}
+}
+
+func TestVoid_GivenRunningPomodoro_WhenStopClicked_ThenVoidedWithDefaultReason(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	c.Stop()
+
+	stopped, _ := subscriber.Last(gopomodoro.Stopped)
+	if !stopped.Voided || stopped.Reason != gopomodoro.DefaultVoidReason {
+		t.Fatalf("expected voided pomodoro with default reason, got %+v", stopped)
+	}
+}
+
+func TestVoid_GivenShortBreak_WhenStopClicked_ThenNotVoided(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	c.Stop()
+
+	stopped, _ := subscriber.Last(gopomodoro.Stopped)
+	if stopped.Voided || stopped.Phase != gopomodoro.ShortBreak {
+		t.Fatalf("expected stopped short break not to be voided, got %+v", stopped)
+	}
+}
@@ -0,0 +1,86 @@
+// Package inventory keeps the Activity Inventory and the To Do Today sheet
+// of the Pomodoro Technique.
+package inventory
+
+import (
+	"fmt"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// Section is the list an item is on.
+type Section int
+
+const (
+	// Inventory holds everything that needs doing some day.
+	Inventory Section = iota
+	// Today holds the activities picked from the inventory for today.
+	Today
+	// Urgent holds unplanned and urgent activities that came up today.
+	Urgent
+)
+
+func (s Section) String() string {
+	switch s {
+	case Inventory:
+		return "inventory"
+	case Today:
+		return "today"
+	case Urgent:
+		return "urgent"
+	default:
+		return fmt.Sprintf("Section(%d)", int(s))
+	}
+}
+
+// ParseSection returns the section named by s, as produced by String.
+func ParseSection(s string) (Section, error) {
+	for _, section := range []Section{Inventory, Today, Urgent} {
+		if section.String() == s {
+			return section, nil
+		}
+	}
+	return Inventory, fmt.Errorf("unknown section %q", s)
+}
+
+func (s Section) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Section) UnmarshalText(text []byte) error {
+	section, err := ParseSection(string(text))
+	if err != nil {
+		return err
+	}
+	*s = section
+	return nil
+}
+
+// Item is one activity.
+type Item struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Tags    []string  `json:"tags,omitempty"`
+	Section Section   `json:"section"`
+	Added   time.Time `json:"added"`
+
+	// Estimate is the number of pomodoros the activity is expected to take.
+	// Zero means no estimate was given. Actual counts the pomodoros completed
+	// against it.
+	Estimate int `json:"estimate,omitempty"`
+	Actual   int `json:"actual,omitempty"`
+
+	// Done is when the activity was finished; zero while it is open.
+	Done time.Time `json:"done,omitzero"`
+}
+
+// IsDone reports whether the activity was finished.
+func (i Item) IsDone() bool {
+	return !i.Done.IsZero()
+}
+
+// Task returns the label for pomodoros spent on the activity.
+func (i Item) Task() gopomodoro.Task {
+	return gopomodoro.Task{Name: i.Name, Tags: i.Tags, Estimate: i.Estimate, Actual: i.Actual}
+}
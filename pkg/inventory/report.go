@@ -0,0 +1,62 @@
+package inventory
+
+import (
+	"sort"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// Accuracy compares estimates with actual pomodoros for the items finished
+// within [From, To).
+type Accuracy struct {
+	From time.Time
+	To   time.Time
+
+	Tasks     int
+	Estimated int
+	Actual    int
+
+	// WithinEstimate counts the items that took no more pomodoros than
+	// estimated.
+	WithinEstimate int
+}
+
+// Ratio is the number of actual pomodoros per estimated one; above 1 means
+// the estimates were too optimistic.
+func (a Accuracy) Ratio() float64 {
+	if a.Estimated == 0 {
+		return 0
+	}
+	return float64(a.Actual) / float64(a.Estimated)
+}
+
+// WeeklyAccuracy groups finished items with an estimate by the week,
+// starting on Monday, in which they were finished, oldest week first.
+func WeeklyAccuracy(items []Item) []Accuracy {
+	weeks := map[time.Time]*Accuracy{}
+	for _, item := range items {
+		if !item.IsDone() || item.Estimate == 0 {
+			continue
+		}
+		from := gopomodoro.WeekOf(item.Done, 0)
+		a, ok := weeks[from]
+		if !ok {
+			a = &Accuracy{From: from, To: from.AddDate(0, 0, 7)}
+			weeks[from] = a
+		}
+		a.Tasks++
+		a.Estimated += item.Estimate
+		a.Actual += item.Actual
+		if item.Actual <= item.Estimate {
+			a.WithinEstimate++
+		}
+	}
+
+	report := make([]Accuracy, 0, len(weeks))
+	for _, a := range weeks {
+		report = append(report, *a)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].From.Before(report[j].From) })
+	return report
+}
@@ -0,0 +1,31 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/inventory"
+)
+
+func TestWeeklyAccuracy_GivenFinishedItems_WhenReported_ThenGroupedByWeekOldestFirst(t *testing.T) {
+	items := []inventory.Item{
+		{Name: "Report", Estimate: 2, Actual: 3, Done: time.Date(2026, 1, 14, 16, 0, 0, 0, time.UTC)},
+		{Name: "Review", Estimate: 1, Actual: 1, Done: time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)},
+		{Name: "Slides", Estimate: 3, Actual: 2, Done: time.Date(2026, 1, 11, 23, 0, 0, 0, time.UTC)},
+		{Name: "No estimate", Actual: 4, Done: time.Date(2026, 1, 6, 10, 0, 0, 0, time.UTC)},
+		{Name: "Open", Estimate: 1, Actual: 5},
+	}
+
+	report := inventory.WeeklyAccuracy(items)
+
+	if len(report) != 2 {
+		t.Fatalf("expected 2 weeks, got %+v", report)
+	}
+	first, second := report[0], report[1]
+	if !first.From.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) || first.Tasks != 2 || first.Estimated != 4 || first.Actual != 3 || first.WithinEstimate != 2 {
+		t.Fatalf("unexpected first week %+v", first)
+	}
+	if second.Tasks != 1 || second.WithinEstimate != 0 || second.Ratio() != 1.5 {
+		t.Fatalf("unexpected second week %+v", second)
+	}
+}
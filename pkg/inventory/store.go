@@ -0,0 +1,253 @@
+package inventory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// FileName is the name of the task store inside the data directory.
+const FileName = "tasks.json"
+
+const version = 1
+
+// ErrNotFound is returned for an ID that is not in the store.
+var ErrNotFound = errors.New("task not found")
+
+// errUnchanged tells update not to write the file.
+var errUnchanged = errors.New("unchanged")
+
+// file is the on-disk format of the store.
+type file struct {
+	Version int    `json:"version"`
+	NextID  int    `json:"next_id"`
+	Items   []Item `json:"items"`
+}
+
+// Store keeps items in a JSON file. The file is re-read whenever it changed
+// on disk, so a running tray picks up items added from the command line.
+// Writes from several processes at the same time are not coordinated.
+type Store struct {
+	Path string
+
+	// Clock is optional; the system clock is used when nil.
+	Clock gopomodoro.Clock
+
+	mu      sync.Mutex
+	cached  file
+	modTime time.Time
+	size    int64
+}
+
+// Add appends a new open item and returns it with its ID set.
+func (s *Store) Add(name string, tags []string, estimate int, section Section) (Item, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Item{}, errors.New("task name must not be empty")
+	}
+	if estimate < 0 {
+		return Item{}, errors.New("estimate must not be negative")
+	}
+	var added Item
+	err := s.update(func(f *file) error {
+		f.NextID++
+		added = Item{
+			ID:       f.NextID,
+			Name:     name,
+			Tags:     tags,
+			Section:  section,
+			Added:    s.now(),
+			Estimate: estimate,
+		}
+		f.Items = append(f.Items, added)
+		return nil
+	})
+	return added, err
+}
+
+// Items returns all items, open and done, ordered by ID.
+func (s *Store) Items() ([]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	items := append([]Item(nil), f.Items...)
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+// Today returns the open items planned for today followed by the open
+// unplanned and urgent ones.
+func (s *Store) Today() ([]Item, error) {
+	items, err := s.Items()
+	if err != nil {
+		return nil, err
+	}
+	var today, urgent []Item
+	for _, item := range items {
+		switch {
+		case item.IsDone():
+		case item.Section == Today:
+			today = append(today, item)
+		case item.Section == Urgent:
+			urgent = append(urgent, item)
+		}
+	}
+	return append(today, urgent...), nil
+}
+
+// Done marks the item as finished.
+func (s *Store) Done(id int) error {
+	return s.modify(id, func(item *Item) {
+		if !item.IsDone() {
+			item.Done = s.now()
+		}
+	})
+}
+
+// MoveToToday moves the item from the inventory onto today's sheet.
+func (s *Store) MoveToToday(id int) error {
+	return s.modify(id, func(item *Item) {
+		if item.Section == Inventory {
+			item.Section = Today
+		}
+	})
+}
+
+// SetEstimate replaces the number of pomodoros the item is expected to take.
+func (s *Store) SetEstimate(id, estimate int) error {
+	if estimate < 0 {
+		return errors.New("estimate must not be negative")
+	}
+	return s.modify(id, func(item *Item) { item.Estimate = estimate })
+}
+
+// CountPomodoro counts a completed pomodoro against the open item called
+// name, preferring items on today's sheet over the inventory. It does
+// nothing if there is no such item.
+func (s *Store) CountPomodoro(name string) error {
+	return s.update(func(f *file) error {
+		match := -1
+		for i, item := range f.Items {
+			if item.Name != name || item.IsDone() {
+				continue
+			}
+			if match < 0 || f.Items[match].Section == Inventory && item.Section != Inventory {
+				match = i
+			}
+		}
+		if match < 0 {
+			return errUnchanged
+		}
+		f.Items[match].Actual++
+		return nil
+	})
+}
+
+func (s *Store) modify(id int, change func(*Item)) error {
+	return s.update(func(f *file) error {
+		for i := range f.Items {
+			if f.Items[i].ID == id {
+				change(&f.Items[i])
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %d", ErrNotFound, id)
+	})
+}
+
+// update applies change to the current contents and writes them back.
+func (s *Store) update(change func(*file) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, err := s.load()
+	if err != nil {
+		return err
+	}
+	f := file{Version: version, NextID: current.NextID, Items: append([]Item(nil), current.Items...)}
+	if err := change(&f); errors.Is(err, errUnchanged) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return s.save(f)
+}
+
+// load returns the contents of the file, reading it only when it changed
+// since the last call. Must be called with mu held.
+func (s *Store) load() (file, error) {
+	info, err := os.Stat(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.cached, s.modTime, s.size = file{}, time.Time{}, 0
+		return s.cached, nil
+	}
+	if err != nil {
+		return file{}, fmt.Errorf("read tasks: %w", err)
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.cached, nil
+	}
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return file{}, fmt.Errorf("read tasks: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return file{}, fmt.Errorf("decode tasks %s: %w", s.Path, err)
+	}
+	if f.Version != version {
+		return file{}, fmt.Errorf("decode tasks %s: unsupported version %d", s.Path, f.Version)
+	}
+	s.cached, s.modTime, s.size = f, info.ModTime(), info.Size()
+	return f, nil
+}
+
+// save atomically replaces the file with f. Must be called with mu held.
+func (s *Store) save(f file) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode tasks: %w", err)
+	}
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create tasks directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(s.Path)+"-*")
+	if err != nil {
+		return fmt.Errorf("create tasks: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write tasks: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync tasks: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close tasks: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return fmt.Errorf("replace tasks: %w", err)
+	}
+	s.cached, s.modTime, s.size = file{}, time.Time{}, 0
+	return nil
+}
+
+func (s *Store) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock.Now()
+	}
+	return time.Now()
+}
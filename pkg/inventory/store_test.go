@@ -0,0 +1,145 @@
+package inventory_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/inventory"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+var day = time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+
+func newStore(t *testing.T) *inventory.Store {
+	return &inventory.Store{
+		Path:  filepath.Join(t.TempDir(), inventory.FileName),
+		Clock: pomotest.NewMockClock(day),
+	}
+}
+
+func TestStore_GivenAddedItems_WhenListed_ThenReturnedInOrderWithIDs(t *testing.T) {
+	store := newStore(t)
+	if _, err := store.Add("Write report", []string{"docs"}, 3, inventory.Inventory); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.Add("Review PR", nil, 1, inventory.Today); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	items, err := store.Items()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(items) != 2 || items[0].ID != 1 || items[0].Estimate != 3 || items[0].Tags[0] != "docs" || items[1].ID != 2 {
+		t.Fatalf("expected the two items with IDs 1 and 2, got %+v", items)
+	}
+	if !items[0].Added.Equal(day) {
+		t.Fatalf("expected item added at %v, got %v", day, items[0].Added)
+	}
+}
+
+func TestStore_GivenEmptyName_WhenAdded_ThenFails(t *testing.T) {
+	if _, err := newStore(t).Add("  ", nil, 0, inventory.Inventory); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestStore_GivenItemsInAllSections_WhenToday_ThenPlannedBeforeUrgentWithoutDone(t *testing.T) {
+	store := newStore(t)
+	urgent, _ := store.Add("Call bank", nil, 0, inventory.Urgent)
+	_, _ = store.Add("Someday", nil, 0, inventory.Inventory)
+	planned, _ := store.Add("Write report", nil, 2, inventory.Inventory)
+	finished, _ := store.Add("Email", nil, 1, inventory.Today)
+	_ = store.MoveToToday(planned.ID)
+	_ = store.Done(finished.ID)
+
+	items, err := store.Today()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(items) != 2 || items[0].ID != planned.ID || items[1].ID != urgent.ID {
+		t.Fatalf("expected Write report then Call bank, got %+v", items)
+	}
+}
+
+func TestStore_GivenUnknownID_WhenDone_ThenNotFound(t *testing.T) {
+	err := newStore(t).Done(42)
+
+	if !errors.Is(err, inventory.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStore_GivenOtherStoreOnSameFile_WhenItAdds_ThenChangeIsSeen(t *testing.T) {
+	store := newStore(t)
+	_, _ = store.Add("Write report", nil, 0, inventory.Today)
+	if items, _ := store.Today(); len(items) != 1 {
+		t.Fatalf("expected 1 item, got %+v", items)
+	}
+	other := &inventory.Store{Path: store.Path}
+
+	_, _ = other.Add("Review PR with a longer name", nil, 0, inventory.Today)
+
+	if items, _ := store.Today(); len(items) != 2 {
+		t.Fatalf("expected the item added by the other store, got %+v", items)
+	}
+}
+
+func TestStore_GivenNoFile_WhenListed_ThenEmpty(t *testing.T) {
+	store := newStore(t)
+
+	items, err := store.Items()
+
+	if err != nil || len(items) != 0 {
+		t.Fatalf("expected no items and no error, got %v, %v", items, err)
+	}
+	if _, err := os.Stat(store.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected listing not to create the file, got %v", err)
+	}
+}
+
+func TestStore_GivenSameNameInInventoryAndToday_WhenPomodoroCounted_ThenTodaysItemCounts(t *testing.T) {
+	store := newStore(t)
+	inInventory, _ := store.Add("Write report", nil, 2, inventory.Inventory)
+	onToday, _ := store.Add("Write report", nil, 2, inventory.Today)
+
+	if err := store.CountPomodoro("Write report"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	items, _ := store.Items()
+	if items[inInventory.ID-1].Actual != 0 || items[onToday.ID-1].Actual != 1 {
+		t.Fatalf("expected only today's item to count, got %+v", items)
+	}
+}
+
+func TestStore_GivenUnknownName_WhenPomodoroCounted_ThenNothingIsWritten(t *testing.T) {
+	store := newStore(t)
+
+	if err := store.CountPomodoro("Not a task"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := os.Stat(store.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no file to be written, got %v", err)
+	}
+}
+
+func TestStore_GivenItem_WhenEstimateSet_ThenTaskCarriesIt(t *testing.T) {
+	store := newStore(t)
+	item, _ := store.Add("Write report", nil, 0, inventory.Today)
+
+	if err := store.SetEstimate(item.ID, 4); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	items, _ := store.Today()
+	if task := items[0].Task(); task.Estimate != 4 || task.Actual != 0 {
+		t.Fatalf("expected estimate of 4, got %#v", task)
+	}
+}
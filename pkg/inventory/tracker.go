@@ -0,0 +1,23 @@
+package inventory
+
+import gopomodoro "github.com/co0p/gopomodoro/pkg"
+
+// Tracker counts every completed pomodoro against the item its task is named
+// after. Skipped pomodoros are not counted. Register it with Cycle.Subscribe.
+type Tracker struct {
+	Store *Store
+
+	// OnError is called when the count cannot be saved. Optional.
+	OnError func(error)
+}
+
+func (t *Tracker) OnEvent(e gopomodoro.Event) {
+	if e.Type != gopomodoro.PhaseCompleted || e.Phase != gopomodoro.Pomodoro || e.Skipped || e.Task.Name == "" {
+		return
+	}
+	if err := t.Store.CountPomodoro(e.Task.Name); err != nil && t.OnError != nil {
+		t.OnError(err)
+	}
+}
+
+var _ gopomodoro.EventSubscriber = (*Tracker)(nil)
@@ -0,0 +1,28 @@
+package inventory_test
+
+import (
+	"testing"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/inventory"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestTracker_GivenPomodorosOnTodaysTask_WhenCompleted_ThenCountedButSkippedAndVoidedAreNot(t *testing.T) {
+	store := newStore(t)
+	item, _ := store.Add("Write report", nil, 2, inventory.Today)
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(&inventory.Tracker{Store: store})
+
+	c.StartTask(item.Task())
+	pomotest.CompleteCycle(c)
+	c.Skip() // short break
+	c.Skip() // second pomodoro
+	c.Skip() // short break
+	c.Stop() // voids the third pomodoro
+
+	items, _ := store.Today()
+	if items[0].Actual != 1 {
+		t.Fatalf("expected 1 pomodoro counted, got %d", items[0].Actual)
+	}
+}
@@ -0,0 +1,167 @@
+// Package journal persists the state of a cycle so that it survives
+// crashes, quitting and reboots.
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// FileName is the name of the journal file inside the state directory.
+const FileName = "state.json"
+
+const version = 1
+
+// Journal stores the latest checkpoint of a cycle in a single JSON file.
+// Saving writes a temporary file next to it and renames it into place, so a
+// crash never leaves a partially written journal behind.
+type Journal struct {
+	Path string
+}
+
+// record is the on-disk format. It is kept separate from
+// gopomodoro.Checkpoint so the file format only changes deliberately.
+type record struct {
+	Version       int                   `json:"version"`
+	State         gopomodoro.CycleState `json:"state"`
+	PomodoroCount int                   `json:"pomodoro_count"`
+	Step          int                   `json:"step,omitempty"`
+	Paused        bool                  `json:"paused,omitempty"`
+	Waiting       bool                  `json:"waiting,omitempty"`
+	Internal      int                   `json:"internal_interruptions,omitempty"`
+	External      int                   `json:"external_interruptions,omitempty"`
+	Extension     time.Duration         `json:"extension,omitempty"`
+	Task          string                `json:"task,omitempty"`
+	Tags          []string              `json:"tags,omitempty"`
+	Estimate      int                   `json:"estimate,omitempty"`
+	Actual        int                   `json:"actual,omitempty"`
+	Profile       string                `json:"profile,omitempty"`
+	StopPending   bool                  `json:"stop_pending,omitempty"`
+	RestUntil     time.Time             `json:"rest_until,omitzero"`
+	PhaseStarted  time.Time             `json:"phase_started,omitzero"`
+	PausedFor     time.Duration         `json:"paused_for,omitempty"`
+	PausedAt      time.Time             `json:"paused_at,omitzero"`
+	Deadline      time.Time             `json:"deadline,omitzero"`
+	Remaining     time.Duration         `json:"remaining,omitempty"`
+	SavedAt       time.Time             `json:"saved_at"`
+}
+
+// Save atomically replaces the journal with cp.
+func (j *Journal) Save(cp gopomodoro.Checkpoint) error {
+	data, err := json.MarshalIndent(record{
+		Version:       version,
+		State:         cp.State,
+		PomodoroCount: cp.PomodoroCount,
+		Step:          cp.Step,
+		Paused:        cp.Paused,
+		Waiting:       cp.Waiting,
+		Internal:      cp.Interruptions.Internal,
+		External:      cp.Interruptions.External,
+		Extension:     cp.Extension,
+		Task:          cp.Task.Name,
+		Tags:          cp.Task.Tags,
+		Estimate:      cp.Task.Estimate,
+		Actual:        cp.Task.Actual,
+		Profile:       cp.Profile,
+		StopPending:   cp.StopPending,
+		RestUntil:     cp.RestUntil,
+		PhaseStarted:  cp.PhaseStarted,
+		PausedFor:     cp.PausedFor,
+		PausedAt:      cp.PausedAt,
+		Deadline:      cp.Deadline,
+		Remaining:     cp.Remaining,
+		SavedAt:       cp.SavedAt,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode journal: %w", err)
+	}
+	return writeAtomic(j.Path, data)
+}
+
+// Load reads the checkpoint saved last. If no journal exists yet, the
+// returned error wraps os.ErrNotExist.
+func (j *Journal) Load() (gopomodoro.Checkpoint, error) {
+	data, err := os.ReadFile(j.Path)
+	if err != nil {
+		return gopomodoro.Checkpoint{}, fmt.Errorf("read journal: %w", err)
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return gopomodoro.Checkpoint{}, fmt.Errorf("decode journal %s: %w", j.Path, err)
+	}
+	if r.Version != version {
+		return gopomodoro.Checkpoint{}, fmt.Errorf("decode journal %s: unsupported version %d", j.Path, r.Version)
+	}
+	return gopomodoro.Checkpoint{
+		State:         r.State,
+		PomodoroCount: r.PomodoroCount,
+		Step:          r.Step,
+		Paused:        r.Paused,
+		Waiting:       r.Waiting,
+		Interruptions: gopomodoro.Interruptions{Internal: r.Internal, External: r.External},
+		Extension:     r.Extension,
+		Task:          gopomodoro.Task{Name: r.Task, Tags: r.Tags, Estimate: r.Estimate, Actual: r.Actual},
+		Profile:       r.Profile,
+		StopPending:   r.StopPending,
+		RestUntil:     r.RestUntil,
+		PhaseStarted:  r.PhaseStarted,
+		PausedFor:     r.PausedFor,
+		PausedAt:      r.PausedAt,
+		Deadline:      r.Deadline,
+		Remaining:     r.Remaining,
+		SavedAt:       r.SavedAt,
+	}, nil
+}
+
+func writeAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create journal directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("create journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace journal: %w", err)
+	}
+	return nil
+}
+
+// Recorder saves a checkpoint of Cycle to Journal after every event except
+// ticks. Register it with Cycle.Subscribe.
+type Recorder struct {
+	Cycle   *gopomodoro.Cycle
+	Journal *Journal
+
+	// OnError is called when a checkpoint cannot be saved. Optional.
+	OnError func(error)
+}
+
+func (r *Recorder) OnEvent(e gopomodoro.Event) {
+	if e.Type == gopomodoro.Ticked {
+		return
+	}
+	if err := r.Journal.Save(r.Cycle.Checkpoint()); err != nil && r.OnError != nil {
+		r.OnError(err)
+	}
+}
+
+var _ gopomodoro.EventSubscriber = (*Recorder)(nil)
@@ -0,0 +1,100 @@
+package journal_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/journal"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestJournal_GivenSavedCheckpoint_WhenLoaded_ThenRoundTrips(t *testing.T) {
+	j := &journal.Journal{Path: filepath.Join(t.TempDir(), "nested", journal.FileName)}
+	cp := gopomodoro.Checkpoint{
+		State:         gopomodoro.ShortBreak,
+		PomodoroCount: 2,
+		Interruptions: gopomodoro.Interruptions{Internal: 1},
+		Extension:     time.Minute,
+		Task:          gopomodoro.Task{Name: "Write report", Tags: []string{"docs"}, Estimate: 3, Actual: 1},
+		Profile:       "deep-work",
+		StopPending:   true,
+		RestUntil:     time.Date(2026, 1, 5, 9, 28, 0, 0, time.UTC),
+		Deadline:      time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC),
+		SavedAt:       time.Date(2026, 1, 5, 9, 26, 0, 0, time.UTC),
+	}
+
+	if err := j.Save(cp); err != nil {
+		t.Fatalf("expected no error saving, got %v", err)
+	}
+	loaded, err := j.Load()
+
+	if err != nil {
+		t.Fatalf("expected no error loading, got %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cp) {
+		t.Fatalf("expected %+v, got %+v", cp, loaded)
+	}
+}
+
+func TestJournal_GivenNoFile_WhenLoaded_ThenNotExist(t *testing.T) {
+	j := &journal.Journal{Path: filepath.Join(t.TempDir(), journal.FileName)}
+
+	_, err := j.Load()
+
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestJournal_GivenCorruptFile_WhenLoaded_ThenError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), journal.FileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	j := &journal.Journal{Path: path}
+
+	if _, err := j.Load(); err == nil {
+		t.Fatal("expected an error for a corrupt journal")
+	}
+}
+
+func TestJournal_GivenRepeatedSaves_WhenDone_ThenOnlyJournalFileRemains(t *testing.T) {
+	dir := t.TempDir()
+	j := &journal.Journal{Path: filepath.Join(dir, journal.FileName)}
+
+	for i := 0; i < 3; i++ {
+		if err := j.Save(gopomodoro.Checkpoint{State: gopomodoro.Pomodoro, PomodoroCount: i}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != journal.FileName {
+		t.Fatalf("expected only %s, got %v", journal.FileName, entries)
+	}
+}
+
+func TestRecorder_GivenSubscribedRecorder_WhenCycleTransitions_ThenJournalHasLatestState(t *testing.T) {
+	j := &journal.Journal{Path: filepath.Join(t.TempDir(), journal.FileName)}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(&journal.Recorder{Cycle: c, Journal: j})
+
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	cp, err := j.Load()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cp.State != gopomodoro.ShortBreak || cp.PomodoroCount != 1 {
+		t.Fatalf("expected ShortBreak after 1 pomodoro, got %+v", cp)
+	}
+}
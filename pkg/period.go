@@ -0,0 +1,20 @@
+package gopomodoro
+
+import "time"
+
+// DayOf returns the start of the day containing t, for days that begin
+// dayStart after midnight, so that a pomodoro at 01:00 can count towards the
+// previous day.
+func DayOf(t time.Time, dayStart time.Duration) time.Time {
+	y, m, d := t.Add(-dayStart).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location()).Add(dayStart)
+}
+
+// WeekOf returns the start of the week, beginning on Monday, containing t,
+// for days that begin dayStart after midnight.
+func WeekOf(t time.Time, dayStart time.Duration) time.Time {
+	shifted := t.Add(-dayStart)
+	y, m, d := shifted.Date()
+	d -= (int(shifted.Weekday()) + 6) % 7
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location()).Add(dayStart)
+}
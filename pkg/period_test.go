@@ -0,0 +1,38 @@
+package gopomodoro_test
+
+import (
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+func TestDayOf_GivenDayStartAtFour_WhenAtOneInTheMorning_ThenPreviousDay(t *testing.T) {
+	wednesdayNight := time.Date(2026, 1, 7, 1, 0, 0, 0, time.UTC)
+
+	day := gopomodoro.DayOf(wednesdayNight, 4*time.Hour)
+
+	if !day.Equal(time.Date(2026, 1, 6, 4, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected Tuesday 04:00, got %v", day)
+	}
+}
+
+func TestWeekOf_GivenSunday_WhenRequested_ThenMondayBefore(t *testing.T) {
+	sunday := time.Date(2026, 1, 11, 20, 0, 0, 0, time.UTC)
+
+	week := gopomodoro.WeekOf(sunday, 0)
+
+	if !week.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected Monday 5 January, got %v", week)
+	}
+}
+
+func TestWeekOf_GivenDayStartAtFour_WhenEarlyMonday_ThenPreviousWeek(t *testing.T) {
+	mondayNight := time.Date(2026, 1, 12, 2, 0, 0, 0, time.UTC)
+
+	week := gopomodoro.WeekOf(mondayNight, 4*time.Hour)
+
+	if !week.Equal(time.Date(2026, 1, 5, 4, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected Monday 5 January 04:00, got %v", week)
+	}
+}
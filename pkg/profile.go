@@ -0,0 +1,86 @@
+package gopomodoro
+
+// Profile bundles the settings for one kind of work, such as deep coding or
+// email triage.
+type Profile struct {
+	Name string
+
+	// Durations configures phase lengths and the long break interval.
+	// Schedule replaces them when it has blocks.
+	Durations Durations
+	Schedule  Schedule
+
+	ConfirmBreaks    bool
+	ConfirmPomodoros bool
+
+	// Silent mutes the Notifier while the profile is active.
+	Silent bool
+}
+
+// SetProfile switches the cycle to p. When Idle or waiting for Start, it
+// takes effect at once; otherwise the running phase keeps its length and p
+// applies from the next phase on. A phase of a kind p's schedule lacks is
+// followed by the next pomodoro after a short break and by the end of the
+// set after a long break; if it is still waiting for Start it is passed over
+// at once. Setting the active profile again cancels a pending switch.
+// Applying p overwrites the cycle's Durations, Schedule, ConfirmBreaks and
+// ConfirmPomodoros.
+func (c *Cycle) SetProfile(p Profile) {
+	c.mu.Lock()
+	c.setProfile(p)
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) setProfile(p Profile) {
+	if c.State == Idle || c.waiting {
+		c.applyProfile(p)
+		return
+	}
+	if p.Name == c.profile {
+		c.pendingProfile = nil
+	} else {
+		c.pendingProfile = &p
+	}
+	c.notifyStateChanged()
+}
+
+// applyProfile replaces the configuration with p and emits ProfileChanged.
+// The current phase is looked up again in the new schedule; a phase waiting
+// for Start takes the length the new profile gives it, or is passed over if
+// the new schedule has no phase of its kind. Must be called with mu held.
+func (c *Cycle) applyProfile(p Profile) {
+	c.pendingProfile = nil
+	c.profile = p.Name
+	c.Durations = p.Durations
+	c.Schedule = p.Schedule
+	c.ConfirmBreaks = p.ConfirmBreaks
+	c.ConfirmPomodoros = p.ConfirmPomodoros
+	c.silent = p.Silent
+	c.steps = nil
+	if c.State != Idle {
+		c.step = c.locateStep(c.step)
+	}
+	planned := c.plans(c.State)
+	if c.waiting && planned {
+		c.TimeLeft = c.phaseDuration(c.State)
+	}
+	changed := c.event(ProfileChanged)
+	changed.Profile = p.Name
+	c.publish(changed)
+	if c.waiting && !planned {
+		c.completePhase(true)
+		if c.State != Idle && !c.waiting {
+			c.startTicker()
+		}
+	}
+	c.notifyStateChanged()
+}
+
+// applyPendingProfile applies the profile chosen while the phase that just
+// ended was running. Must be called with mu held.
+func (c *Cycle) applyPendingProfile() {
+	if c.pendingProfile != nil {
+		c.applyProfile(*c.pendingProfile)
+	}
+}
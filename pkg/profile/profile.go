@@ -0,0 +1,164 @@
+// Package profile reads named profiles from a JSON file and provides a few
+// built-in ones.
+//
+// A profile file lists profiles by name; settings left out keep their
+// defaults, and a profile named like a built-in one replaces it:
+//
+//	{
+//	  "profiles": [
+//	    {"name": "deep-work", "pomodoro": "50m", "short_break": "10m",
+//	     "long_break": "30m", "long_break_interval": 3},
+//	    {"name": "meetings", "schedule": "52-17", "silent": true,
+//	     "auto_start_breaks": false}
+//	  ]
+//	}
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/internal/textduration"
+	"github.com/co0p/gopomodoro/pkg/schedule"
+)
+
+// FileName is the name of the profile file inside the config directory.
+const FileName = "profiles.json"
+
+const version = 1
+
+type file struct {
+	Version  int     `json:"version,omitempty"`
+	Profiles []entry `json:"profiles"`
+}
+
+type entry struct {
+	Name              string                `json:"name"`
+	Pomodoro          textduration.Duration `json:"pomodoro,omitempty"`
+	ShortBreak        textduration.Duration `json:"short_break,omitempty"`
+	LongBreak         textduration.Duration `json:"long_break,omitempty"`
+	LongBreakInterval int                   `json:"long_break_interval,omitempty"`
+
+	// Schedule is a built-in schedule name or a path to a schedule file,
+	// relative to the profile file.
+	Schedule string `json:"schedule,omitempty"`
+
+	Silent             bool  `json:"silent,omitempty"`
+	AutoStartBreaks    *bool `json:"auto_start_breaks,omitempty"`
+	AutoStartPomodoros *bool `json:"auto_start_pomodoros,omitempty"`
+}
+
+// Builtins returns the profiles available without a profile file.
+func Builtins() []gopomodoro.Profile {
+	return []gopomodoro.Profile{
+		{Name: "classic", Durations: gopomodoro.DefaultDurations()},
+		{Name: "deep-work", Durations: gopomodoro.Durations{
+			Pomodoro:          50 * time.Minute,
+			ShortBreak:        10 * time.Minute,
+			LongBreak:         30 * time.Minute,
+			LongBreakInterval: 3,
+		}},
+		{Name: "triage", Durations: gopomodoro.Durations{
+			Pomodoro:          15 * time.Minute,
+			ShortBreak:        3 * time.Minute,
+			LongBreak:         10 * time.Minute,
+			LongBreakInterval: 4,
+		}},
+	}
+}
+
+// Load returns the built-in profiles merged with those in the file at path.
+// A missing file is not an error. Unknown fields are rejected so that typos
+// do not go unnoticed.
+func Load(path string) ([]gopomodoro.Profile, error) {
+	profiles := Builtins()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read profiles: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("decode profiles %s: %w", path, err)
+	}
+	if f.Version != 0 && f.Version != version {
+		return nil, fmt.Errorf("decode profiles %s: unsupported version %d", path, f.Version)
+	}
+
+	for _, e := range f.Profiles {
+		p, err := e.profile(filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if i := index(profiles, p.Name); i >= 0 {
+			profiles[i] = p
+		} else {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+// Find returns the profile called name.
+func Find(profiles []gopomodoro.Profile, name string) (gopomodoro.Profile, bool) {
+	if i := index(profiles, name); i >= 0 {
+		return profiles[i], true
+	}
+	return gopomodoro.Profile{}, false
+}
+
+func index(profiles []gopomodoro.Profile, name string) int {
+	for i, p := range profiles {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e entry) profile(dir string) (gopomodoro.Profile, error) {
+	if e.Name == "" {
+		return gopomodoro.Profile{}, errors.New("profile without a name")
+	}
+	if e.Pomodoro < 0 || e.ShortBreak < 0 || e.LongBreak < 0 || e.LongBreakInterval < 0 {
+		return gopomodoro.Profile{}, fmt.Errorf("profile %q: durations and long break interval must not be negative", e.Name)
+	}
+	p := gopomodoro.Profile{
+		Name: e.Name,
+		Durations: gopomodoro.Durations{
+			Pomodoro:          time.Duration(e.Pomodoro),
+			ShortBreak:        time.Duration(e.ShortBreak),
+			LongBreak:         time.Duration(e.LongBreak),
+			LongBreakInterval: e.LongBreakInterval,
+		},
+		ConfirmBreaks:    e.AutoStartBreaks != nil && !*e.AutoStartBreaks,
+		ConfirmPomodoros: e.AutoStartPomodoros != nil && !*e.AutoStartPomodoros,
+		Silent:           e.Silent,
+	}
+	if e.Schedule != "" {
+		s, ok := schedule.Builtin(e.Schedule)
+		if !ok {
+			path := e.Schedule
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			var err error
+			if s, err = schedule.Load(path); err != nil {
+				return gopomodoro.Profile{}, fmt.Errorf("profile %q: %w", e.Name, err)
+			}
+		}
+		p.Schedule = s
+	}
+	return p, nil
+}
@@ -0,0 +1,88 @@
+package profile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/profile"
+)
+
+func writeProfiles(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), profile.FileName)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_GivenMissingFile_WhenLoaded_ThenReturnsBuiltins(t *testing.T) {
+	profiles, err := profile.Load(filepath.Join(t.TempDir(), profile.FileName))
+
+	if err != nil || len(profiles) != len(profile.Builtins()) {
+		t.Fatalf("expected the built-in profiles, got %+v, %v", profiles, err)
+	}
+}
+
+func TestLoad_GivenFile_WhenLoaded_ThenReplacesAndAddsProfiles(t *testing.T) {
+	path := writeProfiles(t, `{"profiles": [
+		{"name": "deep-work", "pomodoro": "45m"},
+		{"name": "meetings", "schedule": "52-17", "silent": true, "auto_start_breaks": false}
+	]}`)
+
+	profiles, err := profile.Load(path)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	deep, _ := profile.Find(profiles, "deep-work")
+	if deep.Durations.Pomodoro != 45*time.Minute || deep.Durations.ShortBreak != 0 {
+		t.Fatalf("expected deep-work to be replaced, got %+v", deep)
+	}
+	meetings, ok := profile.Find(profiles, "meetings")
+	if !ok || !meetings.Silent || !meetings.ConfirmBreaks || meetings.ConfirmPomodoros || meetings.Schedule.Name != "52-17" {
+		t.Fatalf("expected silent meetings on 52-17 confirming breaks, got %+v", meetings)
+	}
+	if len(profiles) != len(profile.Builtins())+1 {
+		t.Fatalf("expected one profile added to the built-ins, got %d", len(profiles))
+	}
+}
+
+func TestLoad_GivenRelativeScheduleFile_WhenLoaded_ThenResolvedNextToProfiles(t *testing.T) {
+	path := writeProfiles(t, `{"profiles": [{"name": "focus", "schedule": "focus.json"}]}`)
+	schedule := `{"blocks": [{"steps": [{"phase": "Pomodoro", "duration": "45m"}]}]}`
+	_ = os.WriteFile(filepath.Join(filepath.Dir(path), "focus.json"), []byte(schedule), 0o644)
+
+	profiles, err := profile.Load(path)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	focus, _ := profile.Find(profiles, "focus")
+	if steps := focus.Schedule.Steps(); len(steps) != 1 || steps[0].Duration != 45*time.Minute {
+		t.Fatalf("expected the 45m schedule, got %+v", focus.Schedule)
+	}
+}
+
+func TestLoad_GivenInvalidFiles_WhenLoaded_ThenFails(t *testing.T) {
+	for _, tc := range []struct {
+		name, data, problem string
+	}{
+		{"unknown field", `{"profiles": [{"name": "x", "pomodoros": "25m"}]}`, "unknown field"},
+		{"no name", `{"profiles": [{"pomodoro": "25m"}]}`, "without a name"},
+		{"negative", `{"profiles": [{"name": "x", "short_break": "-5m"}]}`, "must not be negative"},
+		{"missing schedule", `{"profiles": [{"name": "x", "schedule": "nope.json"}]}`, "read schedule"},
+		{"version", `{"version": 2, "profiles": []}`, "unsupported version"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := profile.Load(writeProfiles(t, tc.data))
+
+			if err == nil || !strings.Contains(err.Error(), tc.problem) {
+				t.Fatalf("expected error about %q, got %v", tc.problem, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package gopomodoro_test
+
+import (
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+var deepWork = gopomodoro.Profile{
+	Name: "deep-work",
+	Durations: gopomodoro.Durations{
+		Pomodoro:          50 * time.Minute,
+		ShortBreak:        10 * time.Minute,
+		LongBreak:         30 * time.Minute,
+		LongBreakInterval: 3,
+	},
+}
+
+func TestProfile_GivenIdleCycle_WhenSet_ThenAppliesImmediately(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+
+	c.SetProfile(deepWork)
+	c.Start()
+
+	if c.Remaining() != 50*time.Minute {
+		t.Fatalf("expected a 50m pomodoro, got %v", c.Remaining())
+	}
+	changed, ok := subscriber.Last(gopomodoro.ProfileChanged)
+	if !ok || changed.Profile != "deep-work" || c.Snapshot().Profile != "deep-work" {
+		t.Fatalf("expected deep-work to be active, got %+v", changed)
+	}
+}
+
+func TestProfile_GivenRunningPomodoro_WhenSet_ThenAppliesFromNextPhase(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+	c.AdvanceMinute()
+
+	c.SetProfile(deepWork)
+
+	if s := c.Snapshot(); s.Remaining != 24*time.Minute || s.PendingProfile != "deep-work" || s.Profile != "" {
+		t.Fatalf("expected the pomodoro to run on with deep-work pending, got %+v", s)
+	}
+	for range 24 {
+		c.AdvanceMinute()
+	}
+	if s := c.Snapshot(); s.State != gopomodoro.ShortBreak || s.Remaining != 10*time.Minute || s.PendingProfile != "" {
+		t.Fatalf("expected a 10m short break with deep-work active, got %+v", s)
+	}
+}
+
+func TestProfile_GivenPendingProfile_WhenActiveProfileSetAgain_ThenSwitchIsCancelled(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.SetProfile(gopomodoro.Profile{Name: "classic"})
+	c.Start()
+	c.SetProfile(deepWork)
+
+	c.SetProfile(gopomodoro.Profile{Name: "classic"})
+	pomotest.CompleteCycle(c)
+
+	if s := c.Snapshot(); s.Profile != "classic" || s.Remaining != 5*time.Minute {
+		t.Fatalf("expected classic 5m short break, got %+v", s)
+	}
+}
+
+func TestProfile_GivenThirdPomodoroOfDefaultSet_WhenSwitchedToIntervalOfThree_ThenLongBreakFollows(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+	c.Skip()
+	c.Skip()
+	c.Skip()
+	c.Skip()
+
+	c.SetProfile(deepWork)
+	pomotest.CompleteCycle(c)
+
+	if s := c.Snapshot(); s.State != gopomodoro.LongBreak || s.Remaining != 30*time.Minute {
+		t.Fatalf("expected a 30m long break after the third pomodoro, got %+v", s)
+	}
+}
+
+func TestProfile_GivenWaitingForBreak_WhenSet_ThenBreakTakesNewLength(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, ConfirmBreaks: true}
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	c.SetProfile(deepWork)
+
+	if s := c.Snapshot(); !s.Waiting || s.Remaining != 10*time.Minute || s.Profile != "deep-work" {
+		t.Fatalf("expected a 10m break waiting for Start, got %+v", s)
+	}
+}
+
+func TestProfile_GivenPendingProfile_WhenStopped_ThenApplies(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+	c.SetProfile(deepWork)
+
+	c.Stop()
+
+	if s := c.Snapshot(); s.Profile != "deep-work" || s.PendingProfile != "" {
+		t.Fatalf("expected deep-work to be active, got %+v", s)
+	}
+}
+
+func TestProfile_GivenSilentProfile_WhenPhaseCompletes_ThenNotifierStaysQuiet(t *testing.T) {
+	notifier := &pomotest.MockNotifier{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Notifier: notifier}
+	c.SetProfile(gopomodoro.Profile{Name: "meetings", Silent: true})
+	c.Start()
+
+	pomotest.CompleteCycle(c)
+
+	if notifier.NotifyCallCount != 0 {
+		t.Fatalf("expected no notifications, got %d", notifier.NotifyCallCount)
+	}
+}
+
+// fiftyTwoSeventeen has no long break.
+var fiftyTwoSeventeen = gopomodoro.Profile{
+	Name: "52-17",
+	Schedule: gopomodoro.Schedule{Name: "52-17", Blocks: []gopomodoro.Block{{Steps: []gopomodoro.Step{
+		{Phase: gopomodoro.Pomodoro, Duration: 52 * time.Minute},
+		{Phase: gopomodoro.ShortBreak, Duration: 17 * time.Minute},
+	}}}},
+}
+
+// longBreaksOnly has no short break.
+var longBreaksOnly = gopomodoro.Profile{
+	Name: "long-breaks",
+	Schedule: gopomodoro.Schedule{Name: "long-breaks", Blocks: []gopomodoro.Block{{Repeat: 2, Steps: []gopomodoro.Step{
+		{Phase: gopomodoro.Pomodoro, Duration: 40 * time.Minute},
+		{Phase: gopomodoro.LongBreak, Duration: 20 * time.Minute},
+	}}}},
+}
+
+func TestProfile_GivenLongBreak_WhenSwitchedToScheduleWithoutOne_ThenSetEndsAfterIt(t *testing.T) {
+	c, _, subscriber := pomotest.NewCycle(nil)
+	c.Start()
+	for range 7 {
+		c.Skip()
+	}
+
+	c.SetProfile(fiftyTwoSeventeen)
+	pomotest.CompleteCycle(c)
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected the set to end after the long break, got %+v", c.Snapshot())
+	}
+	if _, ok := subscriber.Last(gopomodoro.SetCompleted); !ok {
+		t.Fatal("expected a SetCompleted event")
+	}
+}
+
+func TestProfile_GivenWaitingForLongBreak_WhenSwitchedToScheduleWithoutOne_ThenSetEnds(t *testing.T) {
+	c, _, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) { c.ConfirmBreaks = true })
+	c.Start()
+	for range 3 {
+		pomotest.CompleteCycle(c)
+		c.Start()
+		pomotest.CompleteCycle(c)
+	}
+	pomotest.CompleteCycle(c)
+	if s := c.Snapshot(); s.State != gopomodoro.LongBreak || !s.Waiting {
+		t.Fatalf("expected to wait for the long break, got %+v", s)
+	}
+
+	c.SetProfile(fiftyTwoSeventeen)
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected the set to end instead of the long break, got %+v", c.Snapshot())
+	}
+	if _, ok := subscriber.Last(gopomodoro.SetCompleted); !ok {
+		t.Fatal("expected a SetCompleted event")
+	}
+}
+
+func TestProfile_GivenWaitingForShortBreak_WhenSwitchedToScheduleWithoutOne_ThenNextPomodoroFollows(t *testing.T) {
+	c, _, _ := pomotest.NewCycle(func(c *gopomodoro.Cycle) { c.ConfirmBreaks = true })
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	c.SetProfile(longBreaksOnly)
+
+	if s := c.Snapshot(); s.State != gopomodoro.Pomodoro || s.Waiting || s.Remaining != 40*time.Minute || s.PomodoroCount != 1 {
+		t.Fatalf("expected the second pomodoro to run for 40m, got %+v", s)
+	}
+}
@@ -0,0 +1,83 @@
+package gopomodoro
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRestOwed is returned when a pomodoro may not start yet because the
+// break owed for the previous one is not over.
+var ErrRestOwed = errors.New("break not over")
+
+// BreakPolicy makes breaks mandatory. The zero value leaves them optional.
+type BreakPolicy struct {
+	// MinPortion is the share of the break owed after a pomodoro, between 0
+	// and 1, that must pass before the next pomodoro may start. The time
+	// counts from the end of the pomodoro, whether the break runs, is
+	// skipped or the cycle is stopped.
+	MinPortion float64
+}
+
+// owed returns the mandatory part of a break of length d.
+func (p BreakPolicy) owed(d time.Duration) time.Duration {
+	portion := min(max(p.MinPortion, 0), 1)
+	return time.Duration(float64(d) * portion)
+}
+
+// oweRest starts counting down the rest owed for the pomodoro that ended at
+// end, if the next step is a break. Must be called with mu held.
+func (c *Cycle) oweRest(end time.Time) {
+	plan := c.plan()
+	next := c.step + 1
+	if next >= len(plan) || plan[next].Phase == Pomodoro {
+		return
+	}
+	if owed := c.BreakPolicy.owed(plan[next].Duration); owed > 0 {
+		c.restUntil = end.Add(owed)
+	}
+}
+
+// restLeft returns how much of the owed rest must still pass at t, rounded
+// up to whole seconds. Must be called with mu held.
+func (c *Cycle) restLeft(t time.Time) time.Duration {
+	if c.restUntil.IsZero() || !t.Before(c.restUntil) {
+		return 0
+	}
+	return (c.restUntil.Sub(t) + time.Second - 1).Truncate(time.Second)
+}
+
+// admitRest refuses a pomodoro at t while rest is owed. Must be called with
+// mu held.
+func (c *Cycle) admitRest(t time.Time) error {
+	if left := c.restLeft(t); left > 0 {
+		return fmt.Errorf("%w: rest %s more", ErrRestOwed, left)
+	}
+	return nil
+}
+
+// stopTicker stops the Ticker unless rest is owed, in which case it keeps
+// ticking so observers can show the rest counting down. Must be called with
+// mu held.
+func (c *Cycle) stopTicker() {
+	if c.restLeft(c.now()) > 0 {
+		c.startTicker()
+		return
+	}
+	c.Ticker.Stop()
+}
+
+// countDownRest stops the Ticker once the owed rest is over while no phase
+// is running, dropping the refusal it caused. It reports whether rest was
+// being counted down. Must be called with mu held.
+func (c *Cycle) countDownRest() bool {
+	if c.restUntil.IsZero() {
+		return false
+	}
+	if c.restLeft(c.now()) == 0 {
+		c.restUntil = time.Time{}
+		c.notice = ""
+		c.Ticker.Stop()
+	}
+	return true
+}
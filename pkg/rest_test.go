@@ -0,0 +1,121 @@
+package gopomodoro_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+// restedCycle returns a cycle that owes 60% of each break and has just
+// completed a pomodoro, so 3 of the 5 minutes of short break are owed.
+func restedCycle() (*gopomodoro.Cycle, *pomotest.MockClock, *pomotest.MockTicker) {
+	ticker := &pomotest.MockTicker{}
+	c, clock, _ := pomotest.NewCycle(func(c *gopomodoro.Cycle) {
+		c.Ticker = ticker
+		c.BreakPolicy = gopomodoro.BreakPolicy{MinPortion: 0.6}
+	})
+	c.Start()
+	clock.Advance(25 * time.Minute)
+	c.Tick()
+	return c, clock, ticker
+}
+
+func TestRest_GivenBreakOwed_WhenStoppedAndStarted_ThenRefuses(t *testing.T) {
+	c, clock, ticker := restedCycle()
+	subscriber := &pomotest.MockSubscriber{}
+	c.Subscribe(subscriber)
+	clock.Advance(time.Minute)
+
+	c.Stop()
+	c.Start()
+
+	s := c.Snapshot()
+	if s.State != gopomodoro.Idle || s.RestLeft != 2*time.Minute {
+		t.Fatalf("expected to stay idle with 2m rest left, got %+v", s)
+	}
+	if err := c.StartBlocked(); !errors.Is(err, gopomodoro.ErrRestOwed) {
+		t.Fatalf("expected ErrRestOwed, got %v", err)
+	}
+	refused, ok := subscriber.Last(gopomodoro.StartRefused)
+	if !ok || refused.Reason != "break not over: rest 2m0s more" {
+		t.Fatalf("expected a StartRefused event, got %+v", refused)
+	}
+	if !ticker.Started() {
+		t.Fatal("expected the ticker to keep running while rest is owed")
+	}
+}
+
+func TestRest_GivenRestOver_WhenTicked_ThenStartIsAllowedAgain(t *testing.T) {
+	c, clock, ticker := restedCycle()
+	c.Stop()
+	c.Start()
+	clock.Advance(3 * time.Minute)
+
+	c.Tick()
+
+	s := c.Snapshot()
+	if s.RestLeft != 0 || s.Notice != "" || ticker.Started() {
+		t.Fatalf("expected the rest to be over and the ticker stopped, got %+v", s)
+	}
+	c.Start()
+	if !c.Is(gopomodoro.Pomodoro) {
+		t.Fatalf("expected a pomodoro, got %+v", c.Snapshot())
+	}
+}
+
+func TestRest_GivenBreakSkipped_WhenNextPomodoroDue_ThenWaitsForRest(t *testing.T) {
+	c, clock, _ := restedCycle()
+
+	c.Skip()
+
+	s := c.Snapshot()
+	if s.State != gopomodoro.Pomodoro || !s.Waiting || s.RestLeft != 3*time.Minute {
+		t.Fatalf("expected the pomodoro to wait for 3m rest, got %+v", s)
+	}
+	clock.Advance(3 * time.Minute)
+	c.Start()
+	if s := c.Snapshot(); s.Waiting {
+		t.Fatalf("expected the pomodoro to start once rested, got %+v", s)
+	}
+}
+
+func TestRest_GivenBreakTaken_WhenItEnds_ThenNextPomodoroStarts(t *testing.T) {
+	c, clock, _ := restedCycle()
+
+	clock.Advance(5 * time.Minute)
+	c.Tick()
+
+	if s := c.Snapshot(); s.State != gopomodoro.Pomodoro || s.Waiting {
+		t.Fatalf("expected the next pomodoro to start, got %+v", s)
+	}
+}
+
+func TestRest_GivenNoPolicy_WhenStoppedAndStarted_ThenStartsAtOnce(t *testing.T) {
+	c, clock, _ := pomotest.NewCycle(nil)
+	c.Start()
+	clock.Advance(25 * time.Minute)
+	c.Tick()
+
+	c.Stop()
+	c.Start()
+
+	if !c.Is(gopomodoro.Pomodoro) {
+		t.Fatalf("expected a pomodoro, got %+v", c.Snapshot())
+	}
+}
+
+func TestRest_GivenIdleCheckpointOwingRest_WhenRestored_ThenStillOwed(t *testing.T) {
+	c, clock, _ := restedCycle()
+	c.Stop()
+	cp := c.Checkpoint()
+
+	restored := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Clock: clock, BreakPolicy: c.BreakPolicy}
+	restored.Restore(cp)
+
+	if left := restored.Snapshot().RestLeft; left != 3*time.Minute {
+		t.Fatalf("expected 3m rest left after restoring, got %v", left)
+	}
+}
@@ -0,0 +1,181 @@
+package gopomodoro
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Step is one phase of a schedule.
+type Step struct {
+	Phase    CycleState
+	Duration time.Duration
+}
+
+// Block is a run of steps repeated Repeat times. A Repeat of zero runs the
+// steps once.
+type Block struct {
+	Repeat int
+	Steps  []Step
+}
+
+// Schedule describes the phases of a set in order. The set starts with a
+// pomodoro; once its last step ends the set is complete and the cycle
+// returns to Idle.
+type Schedule struct {
+	Name   string
+	Blocks []Block
+}
+
+// DefaultSchedule returns the traditional rhythm for d: a pomodoro followed
+// by a short break, with a long break instead after every
+// d.LongBreakInterval pomodoros.
+func DefaultSchedule(d Durations) Schedule {
+	d = d.withDefaults()
+	pomodoro := Step{Phase: Pomodoro, Duration: d.Pomodoro}
+	var blocks []Block
+	if d.LongBreakInterval > 1 {
+		blocks = append(blocks, Block{
+			Repeat: d.LongBreakInterval - 1,
+			Steps:  []Step{pomodoro, {Phase: ShortBreak, Duration: d.ShortBreak}},
+		})
+	}
+	blocks = append(blocks, Block{
+		Steps: []Step{pomodoro, {Phase: LongBreak, Duration: d.LongBreak}},
+	})
+	return Schedule{Name: "default", Blocks: blocks}
+}
+
+// Steps returns the steps of the schedule with all repetitions spelled out.
+func (s Schedule) Steps() []Step {
+	var steps []Step
+	for _, b := range s.Blocks {
+		for range max(b.Repeat, 1) {
+			steps = append(steps, b.Steps...)
+		}
+	}
+	return steps
+}
+
+// Validate reports the first problem that would keep the schedule from
+// running: no steps, a first step other than a pomodoro, an unknown phase or
+// a non-positive duration.
+func (s Schedule) Validate() error {
+	if len(s.Steps()) == 0 {
+		return fmt.Errorf("schedule %q: no steps", s.Name)
+	}
+	for i, b := range s.Blocks {
+		if b.Repeat < 0 {
+			return fmt.Errorf("schedule %q: block %d: negative repeat %d", s.Name, i+1, b.Repeat)
+		}
+		for j, step := range b.Steps {
+			if err := step.validate(); err != nil {
+				return fmt.Errorf("schedule %q: block %d, step %d: %w", s.Name, i+1, j+1, err)
+			}
+		}
+	}
+	if first := s.Steps()[0]; first.Phase != Pomodoro {
+		return fmt.Errorf("schedule %q: must start with a pomodoro, not %v", s.Name, first.Phase)
+	}
+	return nil
+}
+
+func (s Step) validate() error {
+	switch s.Phase {
+	case Pomodoro, ShortBreak, LongBreak:
+	default:
+		return fmt.Errorf("invalid phase %v", s.Phase)
+	}
+	if s.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	return nil
+}
+
+// plan returns the steps the cycle runs through. Must be called with mu held.
+func (c *Cycle) plan() []Step {
+	if c.steps == nil {
+		if len(c.Schedule.Blocks) == 0 {
+			c.steps = DefaultSchedule(c.Durations).Steps()
+		} else {
+			c.steps = c.Schedule.Steps()
+		}
+	}
+	return c.steps
+}
+
+// phaseDuration returns the length of the current step if it is a phase
+// of kind s, or else of the next step of that kind. Must be called with mu
+// held.
+func (c *Cycle) phaseDuration(s CycleState) time.Duration {
+	plan := c.plan()
+	for i := range plan {
+		step := plan[(c.step+i)%len(plan)]
+		if step.Phase == s {
+			return step.Duration
+		}
+	}
+	return 0
+}
+
+// locateStep returns step if it matches the state and the number of
+// pomodoros completed in the set, or else finds the step that does, falling
+// back to the first step of the current phase. This keeps checkpoints
+// without a valid step and cycles constructed in a given State on track.
+// If the plan has no step of the current phase's kind, as after switching
+// to a schedule without long breaks, it returns the step before the one
+// that should follow the phase. Must be called with mu held.
+func (c *Cycle) locateStep(step int) int {
+	plan := c.plan()
+	matches := func(i int) bool {
+		if plan[i].Phase != c.State {
+			return false
+		}
+		pomodoros := 0
+		for _, before := range plan[:i] {
+			if before.Phase == Pomodoro {
+				pomodoros++
+			}
+		}
+		return pomodoros == c.pomodoroCount
+	}
+	if step >= 0 && step < len(plan) && matches(step) {
+		return step
+	}
+	for i := range plan {
+		if matches(i) {
+			return i
+		}
+	}
+	for i := range plan {
+		if plan[i].Phase == c.State {
+			return i
+		}
+	}
+	// A short break is followed by the next pomodoro of the set, a long
+	// break by the end of the set.
+	if c.State == ShortBreak {
+		pomodoros := 0
+		for i := range plan {
+			if plan[i].Phase != Pomodoro {
+				continue
+			}
+			if i > 0 && pomodoros >= c.pomodoroCount {
+				return i - 1
+			}
+			pomodoros++
+		}
+	}
+	return len(plan) - 1
+}
+
+// plans reports whether the plan has a step of kind s. Must be called with
+// mu held.
+func (c *Cycle) plans(s CycleState) bool {
+	for _, step := range c.plan() {
+		if step.Phase == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// Package schedule reads declarative schedules from JSON files and provides
+// a few well-known ones.
+//
+// A schedule file lists blocks of steps, each repeated a number of times:
+//
+//	{
+//	  "name": "3x50 then 30",
+//	  "blocks": [
+//	    {"repeat": 2, "steps": [
+//	      {"phase": "Pomodoro", "duration": "50m"},
+//	      {"phase": "ShortBreak", "duration": "10m"}
+//	    ]},
+//	    {"steps": [
+//	      {"phase": "Pomodoro", "duration": "50m"},
+//	      {"phase": "LongBreak", "duration": "30m"}
+//	    ]}
+//	  ]
+//	}
+package schedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/internal/textduration"
+)
+
+type file struct {
+	Name   string  `json:"name"`
+	Blocks []block `json:"blocks"`
+}
+
+type block struct {
+	Repeat int    `json:"repeat,omitempty"`
+	Steps  []step `json:"steps"`
+}
+
+type step struct {
+	Phase    gopomodoro.CycleState `json:"phase"`
+	Duration textduration.Duration `json:"duration"`
+}
+
+// Parse decodes and validates a schedule. Unknown fields are rejected so
+// that typos do not go unnoticed.
+func Parse(data []byte) (gopomodoro.Schedule, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return gopomodoro.Schedule{}, fmt.Errorf("decode schedule: %w", err)
+	}
+	s := gopomodoro.Schedule{Name: f.Name}
+	for _, b := range f.Blocks {
+		steps := make([]gopomodoro.Step, 0, len(b.Steps))
+		for _, st := range b.Steps {
+			steps = append(steps, gopomodoro.Step{Phase: st.Phase, Duration: time.Duration(st.Duration)})
+		}
+		s.Blocks = append(s.Blocks, gopomodoro.Block{Repeat: b.Repeat, Steps: steps})
+	}
+	if err := s.Validate(); err != nil {
+		return gopomodoro.Schedule{}, err
+	}
+	return s, nil
+}
+
+// Load reads the schedule file at path. The schedule is named after the
+// file if it does not name itself.
+func Load(path string) (gopomodoro.Schedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return gopomodoro.Schedule{}, fmt.Errorf("read schedule: %w", err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return gopomodoro.Schedule{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if s.Name == "" {
+		s.Name = path
+	}
+	return s, nil
+}
+
+// Builtin returns the well-known schedule called name.
+func Builtin(name string) (gopomodoro.Schedule, bool) {
+	s, ok := builtins()[name]
+	return s, ok
+}
+
+// Names returns the names of the built-in schedules in alphabetical order.
+func Names() []string {
+	var names []string
+	for name := range builtins() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func builtins() map[string]gopomodoro.Schedule {
+	schedules := []gopomodoro.Schedule{
+		gopomodoro.DefaultSchedule(gopomodoro.DefaultDurations()),
+		{
+			Name: "52-17",
+			Blocks: []gopomodoro.Block{{Steps: []gopomodoro.Step{
+				{Phase: gopomodoro.Pomodoro, Duration: 52 * time.Minute},
+				{Phase: gopomodoro.ShortBreak, Duration: 17 * time.Minute},
+			}}},
+		},
+		{
+			Name: "ultradian",
+			Blocks: []gopomodoro.Block{{Repeat: 3, Steps: []gopomodoro.Step{
+				{Phase: gopomodoro.Pomodoro, Duration: 90 * time.Minute},
+				{Phase: gopomodoro.LongBreak, Duration: 20 * time.Minute},
+			}}},
+		},
+		{
+			Name: "3x50",
+			Blocks: []gopomodoro.Block{
+				{Repeat: 2, Steps: []gopomodoro.Step{
+					{Phase: gopomodoro.Pomodoro, Duration: 50 * time.Minute},
+					{Phase: gopomodoro.ShortBreak, Duration: 10 * time.Minute},
+				}},
+				{Steps: []gopomodoro.Step{
+					{Phase: gopomodoro.Pomodoro, Duration: 50 * time.Minute},
+					{Phase: gopomodoro.LongBreak, Duration: 30 * time.Minute},
+				}},
+			},
+		},
+	}
+	byName := make(map[string]gopomodoro.Schedule, len(schedules))
+	for _, s := range schedules {
+		byName[s.Name] = s
+	}
+	return byName
+}
@@ -0,0 +1,77 @@
+package schedule_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/schedule"
+)
+
+func TestParse_GivenValidFile_WhenParsed_ThenStepsAreRepeated(t *testing.T) {
+	data := []byte(`{
+		"name": "52/17",
+		"blocks": [{"repeat": 2, "steps": [
+			{"phase": "Pomodoro", "duration": "52m"},
+			{"phase": "ShortBreak", "duration": "17m"}
+		]}]
+	}`)
+
+	s, err := schedule.Parse(data)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	steps := s.Steps()
+	if s.Name != "52/17" || len(steps) != 4 || steps[2].Duration != 52*time.Minute || steps[3].Phase != gopomodoro.ShortBreak {
+		t.Fatalf("expected 52/17 twice, got %+v", s)
+	}
+}
+
+func TestParse_GivenInvalidFiles_WhenParsed_ThenFails(t *testing.T) {
+	for _, tc := range []struct {
+		name, data, problem string
+	}{
+		{"unknown field", `{"blocks": [{"steps": [{"phase": "Pomodoro", "duration": "25m", "length": 3}]}]}`, "unknown field"},
+		{"unknown phase", `{"blocks": [{"steps": [{"phase": "Nap", "duration": "25m"}]}]}`, "unknown cycle state"},
+		{"bad duration", `{"blocks": [{"steps": [{"phase": "Pomodoro", "duration": "25"}]}]}`, "missing unit"},
+		{"no steps", `{"name": "empty", "blocks": []}`, "no steps"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := schedule.Parse([]byte(tc.data))
+
+			if err == nil || !strings.Contains(err.Error(), tc.problem) {
+				t.Fatalf("expected error about %q, got %v", tc.problem, err)
+			}
+		})
+	}
+}
+
+func TestLoad_GivenUnnamedSchedule_WhenLoaded_ThenNamedAfterFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "focus.json")
+	_ = os.WriteFile(path, []byte(`{"blocks": [{"steps": [{"phase": "Pomodoro", "duration": "45m"}]}]}`), 0o644)
+
+	s, err := schedule.Load(path)
+
+	if err != nil || s.Name != path {
+		t.Fatalf("expected schedule named %q, got %+v, %v", path, s, err)
+	}
+}
+
+func TestBuiltin_GivenEveryName_WhenLookedUp_ThenScheduleIsValid(t *testing.T) {
+	for _, name := range schedule.Names() {
+		s, ok := schedule.Builtin(name)
+		if !ok {
+			t.Fatalf("expected built-in schedule %q", name)
+		}
+		if err := s.Validate(); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", name, err)
+		}
+	}
+	if _, ok := schedule.Builtin("default"); !ok {
+		t.Fatal("expected a default schedule")
+	}
+}
@@ -0,0 +1,141 @@
+package gopomodoro_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func phases(steps []gopomodoro.Step) []gopomodoro.CycleState {
+	var states []gopomodoro.CycleState
+	for _, s := range steps {
+		states = append(states, s.Phase)
+	}
+	return states
+}
+
+func TestSchedule_GivenDefaultDurations_WhenExpanded_ThenLongBreakFollowsFourthPomodoro(t *testing.T) {
+	steps := gopomodoro.DefaultSchedule(gopomodoro.DefaultDurations()).Steps()
+
+	P, S, L := gopomodoro.Pomodoro, gopomodoro.ShortBreak, gopomodoro.LongBreak
+	expected := []gopomodoro.CycleState{P, S, P, S, P, S, P, L}
+	if got := phases(steps); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if steps[7].Duration != 15*time.Minute {
+		t.Fatalf("expected 15m long break, got %v", steps[7].Duration)
+	}
+}
+
+func TestSchedule_GivenLongBreakIntervalOfOne_WhenExpanded_ThenEveryPomodoroIsFollowedByLongBreak(t *testing.T) {
+	steps := gopomodoro.DefaultSchedule(gopomodoro.Durations{LongBreakInterval: 1}).Steps()
+
+	expected := []gopomodoro.CycleState{gopomodoro.Pomodoro, gopomodoro.LongBreak}
+	if got := phases(steps); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSchedule_GivenInvalidSchedules_WhenValidated_ThenExplainsTheProblem(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schedule gopomodoro.Schedule
+		problem  string
+	}{
+		{"empty", gopomodoro.Schedule{}, "no steps"},
+		{"starts with break", gopomodoro.Schedule{Blocks: []gopomodoro.Block{{Steps: []gopomodoro.Step{
+			{Phase: gopomodoro.ShortBreak, Duration: time.Minute},
+			{Phase: gopomodoro.Pomodoro, Duration: time.Minute},
+		}}}}, "must start with a pomodoro"},
+		{"idle step", gopomodoro.Schedule{Blocks: []gopomodoro.Block{{Steps: []gopomodoro.Step{
+			{Phase: gopomodoro.Pomodoro, Duration: time.Minute},
+			{Phase: gopomodoro.Idle, Duration: time.Minute},
+		}}}}, "block 1, step 2: invalid phase"},
+		{"zero duration", gopomodoro.Schedule{Blocks: []gopomodoro.Block{{Steps: []gopomodoro.Step{
+			{Phase: gopomodoro.Pomodoro},
+		}}}}, "duration must be positive"},
+		{"negative repeat", gopomodoro.Schedule{Blocks: []gopomodoro.Block{{Repeat: -1, Steps: []gopomodoro.Step{
+			{Phase: gopomodoro.Pomodoro, Duration: time.Minute},
+		}}}}, "negative repeat"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.schedule.Validate()
+
+			if err == nil || !strings.Contains(err.Error(), tc.problem) {
+				t.Fatalf("expected error about %q, got %v", tc.problem, err)
+			}
+		})
+	}
+}
+
+func TestCycle_GivenThreeByFiftyThenThirty_WhenRun_ThenLongBreakAfterThirdPomodoroAndSetCompletes(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{
+		Ticker: &pomotest.MockTicker{},
+		Schedule: gopomodoro.Schedule{Blocks: []gopomodoro.Block{
+			{Repeat: 3, Steps: []gopomodoro.Step{{Phase: gopomodoro.Pomodoro, Duration: 50 * time.Minute}}},
+			{Steps: []gopomodoro.Step{{Phase: gopomodoro.LongBreak, Duration: 30 * time.Minute}}},
+		}},
+	}
+	c.Subscribe(subscriber)
+	c.Start()
+
+	for range 3 {
+		if c.Remaining() != 50*time.Minute {
+			t.Fatalf("expected a 50m pomodoro, got %v in %v", c.Remaining(), c.Snapshot().State)
+		}
+		pomotest.CompleteCycle(c)
+	}
+	if !c.Is(gopomodoro.LongBreak) || c.Remaining() != 30*time.Minute {
+		t.Fatalf("expected 30m long break, got %+v", c.Snapshot())
+	}
+	pomotest.CompleteCycle(c)
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected set to complete, got %v", c.Snapshot().State)
+	}
+	if _, ok := subscriber.Last(gopomodoro.SetCompleted); !ok {
+		t.Fatal("expected SetCompleted")
+	}
+}
+
+func TestCycle_GivenScheduleWithDifferentPomodoroLengths_WhenBreakRuns_ThenPhaseDurationIsTheNextPomodoro(t *testing.T) {
+	c := &gopomodoro.Cycle{
+		Ticker: &pomotest.MockTicker{},
+		Schedule: gopomodoro.Schedule{Blocks: []gopomodoro.Block{{Steps: []gopomodoro.Step{
+			{Phase: gopomodoro.Pomodoro, Duration: 50 * time.Minute},
+			{Phase: gopomodoro.ShortBreak, Duration: 10 * time.Minute},
+			{Phase: gopomodoro.Pomodoro, Duration: 30 * time.Minute},
+		}}}},
+	}
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	if got := c.PhaseDuration(gopomodoro.Pomodoro); got != 30*time.Minute {
+		t.Fatalf("expected the next pomodoro to last 30m, got %v", got)
+	}
+}
+
+func TestCheckpoint_GivenCheckpointWithoutStep_WhenRestored_ThenStepIsLocatedFromPomodoroCount(t *testing.T) {
+	c, _, _ := pomotest.NewCycle(nil)
+
+	c.Restore(gopomodoro.Checkpoint{
+		State:         gopomodoro.ShortBreak,
+		PomodoroCount: 3,
+		Paused:        true,
+		Remaining:     2 * time.Minute,
+	})
+	c.Skip()
+
+	if snapshot := c.Snapshot(); snapshot.State != gopomodoro.Pomodoro || snapshot.Remaining != 25*time.Minute {
+		t.Fatalf("expected the fourth pomodoro, got %+v", snapshot)
+	}
+	c.Skip()
+	if !c.Is(gopomodoro.LongBreak) {
+		t.Fatalf("expected a long break after the fourth pomodoro, got %v", c.Snapshot().State)
+	}
+}
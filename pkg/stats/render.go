@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteText prints the report as a table.
+func WriteText(w io.Writer, r Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tPomodoros\tFocus\tSkipped\tVoided\tCompletion\tPer set")
+	for _, row := range []struct {
+		name string
+		s    Summary
+	}{
+		{"Today", r.Today},
+		{"This week", r.Week},
+		{"This month", r.Month},
+	} {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%d\t%.0f%%\t%.1f\n",
+			row.name,
+			row.s.Completed,
+			formatFocus(row.s.Focus),
+			row.s.Skipped,
+			row.s.Voided,
+			row.s.CompletionRatio()*100,
+			row.s.PerSet(),
+		)
+	}
+	return tw.Flush()
+}
+
+// formatFocus renders d in whole minutes, e.g. "2h05m" or "45m".
+func formatFocus(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// summaryJSON is the JSON form of a Summary, meant for scripts.
+type summaryJSON struct {
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	Completed       int       `json:"completed"`
+	Skipped         int       `json:"skipped"`
+	Voided          int       `json:"voided"`
+	FocusMinutes    int       `json:"focus_minutes"`
+	CompletionRatio float64   `json:"completion_ratio"`
+	Sets            int       `json:"sets"`
+	PerSet          float64   `json:"pomodoros_per_set"`
+}
+
+func (s Summary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(summaryJSON{
+		From:            s.From,
+		To:              s.To,
+		Completed:       s.Completed,
+		Skipped:         s.Skipped,
+		Voided:          s.Voided,
+		FocusMinutes:    int(s.Focus / time.Minute),
+		CompletionRatio: s.CompletionRatio(),
+		Sets:            s.Sets,
+		PerSet:          s.PerSet(),
+	})
+}
+
+// WriteJSON prints the report as a single JSON object.
+func WriteJSON(w io.Writer, r Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Today Summary `json:"today"`
+		Week  Summary `json:"week"`
+		Month Summary `json:"month"`
+	}{r.Today, r.Week, r.Month})
+}
@@ -0,0 +1,133 @@
+// Package stats summarises the session history into daily, weekly and
+// monthly totals.
+package stats
+
+import (
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/history"
+)
+
+// Summary totals the pomodoros that started within [From, To).
+type Summary struct {
+	From time.Time
+	To   time.Time
+
+	// Completed counts pomodoros that ran to the end. Skipped counts those
+	// ended early through Skip, which count towards the set but not as
+	// focused work. Voided counts abandoned pomodoros.
+	Completed int
+	Skipped   int
+	Voided    int
+
+	// Focus is the time spent in completed pomodoros, excluding pauses.
+	Focus time.Duration
+
+	// Sets counts the sets in which the first pomodoro was completed or
+	// skipped.
+	Sets int
+}
+
+// CompletionRatio is the share of finished pomodoros that were completed
+// rather than voided, between 0 and 1. Skipped pomodoros are left out.
+func (s Summary) CompletionRatio() float64 {
+	total := s.Completed + s.Voided
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Completed) / float64(total)
+}
+
+// PerSet is the average number of completed pomodoros per set.
+func (s Summary) PerSet() float64 {
+	if s.Sets == 0 {
+		return 0
+	}
+	return float64(s.Completed) / float64(s.Sets)
+}
+
+// Summarize totals the pomodoro records that started within [from, to).
+// Breaks are ignored.
+func Summarize(records []history.Record, from, to time.Time) Summary {
+	s := Summary{From: from, To: to}
+	for _, r := range records {
+		if r.Phase != gopomodoro.Pomodoro || r.Start.Before(from) || !r.Start.Before(to) {
+			continue
+		}
+		switch r.Outcome {
+		case history.Completed:
+			s.Completed++
+			s.Focus += r.Actual
+		case history.Skipped:
+			s.Skipped++
+		case history.Voided:
+			s.Voided++
+		}
+		if r.Pomodoro == 1 && (r.Outcome == history.Completed || r.Outcome == history.Skipped) {
+			s.Sets++
+		}
+	}
+	return s
+}
+
+// Report holds the summaries of the day, week and month containing a
+// point in time.
+type Report struct {
+	Today Summary
+	Week  Summary
+	Month Summary
+}
+
+// Periods configures where reporting periods begin.
+type Periods struct {
+	// DayStart is the time after midnight at which a day begins, so that
+	// night owls can count a pomodoro at 01:00 towards the previous day.
+	DayStart time.Duration
+}
+
+// Day returns the bounds of the day containing t.
+func (p Periods) Day(t time.Time) (from, to time.Time) {
+	from = gopomodoro.DayOf(t, p.DayStart)
+	return from, from.AddDate(0, 0, 1)
+}
+
+// Week returns the bounds of the week, starting on Monday, containing t.
+func (p Periods) Week(t time.Time) (from, to time.Time) {
+	from = gopomodoro.WeekOf(t, p.DayStart)
+	return from, from.AddDate(0, 0, 7)
+}
+
+// Month returns the bounds of the calendar month containing t.
+func (p Periods) Month(t time.Time) (from, to time.Time) {
+	y, m, _ := t.Add(-p.DayStart).Date()
+	from = p.at(y, m, 1, t.Location())
+	return from, p.at(y, m+1, 1, t.Location())
+}
+
+// Since returns the earliest time any period containing t begins at.
+func (p Periods) Since(t time.Time) time.Time {
+	week, _ := p.Week(t)
+	month, _ := p.Month(t)
+	if week.Before(month) {
+		return week
+	}
+	return month
+}
+
+// Report summarises records for the day, week and month containing now.
+func (p Periods) Report(records []history.Record, now time.Time) Report {
+	dayFrom, dayTo := p.Day(now)
+	weekFrom, weekTo := p.Week(now)
+	monthFrom, monthTo := p.Month(now)
+	return Report{
+		Today: Summarize(records, dayFrom, dayTo),
+		Week:  Summarize(records, weekFrom, weekTo),
+		Month: Summarize(records, monthFrom, monthTo),
+	}
+}
+
+func (p Periods) at(y int, m time.Month, d int, loc *time.Location) time.Time {
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	return midnight.Add(p.DayStart)
+}
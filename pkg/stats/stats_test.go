@@ -0,0 +1,138 @@
+package stats_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/history"
+	"github.com/co0p/gopomodoro/pkg/stats"
+)
+
+// monday is Monday 5 January 2026 at 10:00.
+var monday = time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+func pomodoro(start time.Time, index int, outcome history.Outcome) history.Record {
+	return history.Record{
+		Start:    start,
+		End:      start.Add(25 * time.Minute),
+		Phase:    gopomodoro.Pomodoro,
+		Pomodoro: index,
+		Planned:  25 * time.Minute,
+		Actual:   25 * time.Minute,
+		Outcome:  outcome,
+	}
+}
+
+func TestSummarize_GivenMixedRecords_WhenSummarized_ThenCountsPomodorosOnly(t *testing.T) {
+	records := []history.Record{
+		pomodoro(monday, 1, history.Completed),
+		{Start: monday.Add(25 * time.Minute), Phase: gopomodoro.ShortBreak, Actual: 5 * time.Minute, Outcome: history.Completed},
+		pomodoro(monday.Add(30*time.Minute), 2, history.Skipped),
+		pomodoro(monday.Add(time.Hour), 3, history.Voided),
+		pomodoro(monday.Add(2*time.Hour), 1, history.Completed),
+	}
+
+	s := stats.Summarize(records, monday, monday.Add(24*time.Hour))
+
+	if s.Completed != 2 || s.Skipped != 1 || s.Voided != 1 || s.Sets != 2 || s.Focus != 50*time.Minute {
+		t.Fatalf("expected 2 completed, 1 skipped, 1 voided, 2 sets, 50m focus, got %+v", s)
+	}
+	if s.CompletionRatio() != 2.0/3 {
+		t.Fatalf("expected completion ratio 2/3, got %v", s.CompletionRatio())
+	}
+	if s.PerSet() != 1 {
+		t.Fatalf("expected 1 pomodoro per set, got %v", s.PerSet())
+	}
+}
+
+func TestSummarize_GivenNoRecords_WhenSummarized_ThenRatiosAreZero(t *testing.T) {
+	s := stats.Summarize(nil, monday, monday.Add(time.Hour))
+
+	if s.CompletionRatio() != 0 || s.PerSet() != 0 {
+		t.Fatalf("expected zero ratios, got %v and %v", s.CompletionRatio(), s.PerSet())
+	}
+}
+
+func TestPeriods_GivenDayStartAtFour_WhenAtOneInTheMorning_ThenCountsTowardsPreviousDay(t *testing.T) {
+	p := stats.Periods{DayStart: 4 * time.Hour}
+	tuesdayNight := time.Date(2026, 1, 7, 1, 0, 0, 0, time.UTC)
+
+	from, to := p.Day(tuesdayNight)
+
+	if !from.Equal(time.Date(2026, 1, 6, 4, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2026, 1, 7, 4, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected Tuesday 04:00 to Wednesday 04:00, got %v to %v", from, to)
+	}
+}
+
+func TestPeriods_GivenSunday_WhenWeekRequested_ThenWeekStartsOnMonday(t *testing.T) {
+	sunday := time.Date(2026, 1, 11, 20, 0, 0, 0, time.UTC)
+
+	from, to := stats.Periods{}.Week(sunday)
+
+	if !from.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected Monday 5 to Monday 12 January, got %v to %v", from, to)
+	}
+}
+
+func TestPeriods_GivenDecember_WhenMonthRequested_ThenEndsInJanuary(t *testing.T) {
+	from, to := stats.Periods{}.Month(time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC))
+
+	if !from.Equal(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected December, got %v to %v", from, to)
+	}
+}
+
+func TestPeriods_GivenRecords_WhenReported_ThenSplitsByPeriod(t *testing.T) {
+	records := []history.Record{
+		pomodoro(time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC), 1, history.Completed),
+		pomodoro(time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC), 1, history.Completed),
+		pomodoro(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), 1, history.Completed),
+	}
+
+	r := stats.Periods{}.Report(records, monday)
+
+	if r.Today.Completed != 1 || r.Week.Completed != 1 || r.Month.Completed != 2 {
+		t.Fatalf("expected 1 today, 1 this week, 2 this month, got %d, %d, %d", r.Today.Completed, r.Week.Completed, r.Month.Completed)
+	}
+}
+
+func TestWriteText_GivenReport_WhenWritten_ThenShowsRowPerPeriod(t *testing.T) {
+	r := stats.Periods{}.Report([]history.Record{
+		pomodoro(monday.Add(-3*time.Hour), 1, history.Completed),
+		pomodoro(monday.Add(-2*time.Hour), 2, history.Voided),
+	}, monday)
+	var out bytes.Buffer
+
+	if err := stats.WriteText(&out, r); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header and 3 rows, got %q", out.String())
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "Today 1 25m 0 1 50% 1.0" {
+		t.Fatalf("expected today's totals, got %q", lines[1])
+	}
+}
+
+func TestWriteJSON_GivenReport_WhenWritten_ThenFocusIsInMinutes(t *testing.T) {
+	r := stats.Periods{}.Report([]history.Record{pomodoro(monday, 1, history.Completed)}, monday)
+	var out bytes.Buffer
+
+	if err := stats.WriteJSON(&out, r); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var decoded map[string]map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if decoded["today"]["focus_minutes"] != 25.0 || decoded["month"]["completion_ratio"] != 1.0 {
+		t.Fatalf("expected 25 focus minutes and full completion, got %v", decoded)
+	}
+}
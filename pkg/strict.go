@@ -0,0 +1,42 @@
+package gopomodoro
+
+import "time"
+
+// DefaultAbandonTimeout is how long Abandon confirms a Stop in Strict mode.
+const DefaultAbandonTimeout = 10 * time.Second
+
+// requestAbandon asks for confirmation before a pomodoro is abandoned in
+// Strict mode; Abandon confirms it until the timeout passes. Must be
+// called with mu held.
+func (c *Cycle) requestAbandon() {
+	timeout := c.AbandonTimeout
+	if timeout <= 0 {
+		timeout = DefaultAbandonTimeout
+	}
+	c.abandonBy = c.now().Add(timeout)
+	c.emit(AbandonRequested)
+	c.notifyStateChanged()
+}
+
+// abandonPending reports whether a Stop awaits confirmation through
+// Abandon. Must be called with mu held.
+func (c *Cycle) abandonPending() bool {
+	return !c.abandonBy.IsZero() && c.now().Before(c.abandonBy)
+}
+
+// Abandon confirms a Stop of the running pomodoro in Strict mode, voiding it
+// with the time it ran. It has no effect unless Stop asked for confirmation
+// within the AbandonTimeout.
+func (c *Cycle) Abandon() {
+	c.mu.Lock()
+	c.abandon()
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) abandon() {
+	if !c.abandonPending() || c.State != Pomodoro || c.waiting {
+		return
+	}
+	c.stop(DefaultVoidReason)
+}
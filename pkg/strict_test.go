@@ -0,0 +1,123 @@
+package gopomodoro_test
+
+import (
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+// strictCycle returns a strict cycle whose pomodoro has run for 12m30s.
+func strictCycle() (*gopomodoro.Cycle, *pomotest.MockClock, *pomotest.MockSubscriber) {
+	c, clock, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) { c.Strict = true })
+	c.Start()
+	clock.Advance(12*time.Minute + 30*time.Second)
+	return c, clock, subscriber
+}
+
+func TestStrict_GivenRunningPomodoro_WhenStopped_ThenAsksForConfirmation(t *testing.T) {
+	c, _, subscriber := strictCycle()
+
+	c.Stop()
+
+	s := c.Snapshot()
+	if s.State != gopomodoro.Pomodoro || !s.AbandonPending {
+		t.Fatalf("expected the pomodoro to keep running pending confirmation, got %+v", s)
+	}
+	if _, ok := subscriber.Last(gopomodoro.AbandonRequested); !ok {
+		t.Fatal("expected an AbandonRequested event")
+	}
+	if _, ok := subscriber.Last(gopomodoro.Stopped); ok {
+		t.Fatal("expected no Stopped event before confirmation")
+	}
+}
+
+func TestStrict_GivenStopRequested_WhenAbandoned_ThenVoidedWithElapsedTime(t *testing.T) {
+	c, clock, subscriber := strictCycle()
+	c.Stop()
+	clock.Advance(5 * time.Second)
+
+	c.Abandon()
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected to be idle, got %+v", c.Snapshot())
+	}
+	stopped, ok := subscriber.Last(gopomodoro.Stopped)
+	if !ok || !stopped.Voided || stopped.Reason != gopomodoro.DefaultVoidReason || stopped.Elapsed != 12*time.Minute+35*time.Second {
+		t.Fatalf("expected a voided pomodoro with the elapsed time, got %+v", stopped)
+	}
+}
+
+func TestStrict_GivenRunningPomodoro_WhenVoided_ThenElapsedTimeRecorded(t *testing.T) {
+	c, _, subscriber := strictCycle()
+
+	c.Void("phone call")
+
+	stopped, ok := subscriber.Last(gopomodoro.Stopped)
+	if !ok || !stopped.Voided || stopped.Reason != "phone call" || stopped.Elapsed != 12*time.Minute+30*time.Second {
+		t.Fatalf("expected a voided pomodoro with the elapsed time, got %+v", stopped)
+	}
+}
+
+func TestStrict_GivenStopRequested_WhenTimeoutPasses_ThenAbandonHasNoEffect(t *testing.T) {
+	c, clock, _ := strictCycle()
+	c.Stop()
+	clock.Advance(gopomodoro.DefaultAbandonTimeout)
+
+	c.Abandon()
+
+	s := c.Snapshot()
+	if s.State != gopomodoro.Pomodoro || s.AbandonPending {
+		t.Fatalf("expected the pomodoro to keep running without a pending stop, got %+v", s)
+	}
+}
+
+func TestStrict_GivenNoStopRequested_WhenAbandoned_ThenNothingHappens(t *testing.T) {
+	c, _, _ := strictCycle()
+
+	c.Abandon()
+
+	if !c.Is(gopomodoro.Pomodoro) {
+		t.Fatal("expected Abandon without Stop to have no effect")
+	}
+}
+
+func TestStrict_GivenRunningPomodoro_WhenPaused_ThenKeepsRunning(t *testing.T) {
+	c, _, _ := strictCycle()
+
+	c.Pause()
+
+	if c.IsPaused() {
+		t.Fatal("expected a pomodoro not to pause in strict mode")
+	}
+}
+
+func TestStrict_GivenRunningPomodoro_WhenSkipped_ThenKeepsRunning(t *testing.T) {
+	c, _, subscriber := strictCycle()
+
+	c.Skip()
+
+	s := c.Snapshot()
+	if s.State != gopomodoro.Pomodoro || s.PomodoroCount != 0 || s.Progress.Today != 0 {
+		t.Fatalf("expected the pomodoro to keep running uncounted, got %+v", s)
+	}
+	if _, ok := subscriber.Last(gopomodoro.PhaseCompleted); ok {
+		t.Fatal("expected no PhaseCompleted event")
+	}
+}
+
+func TestStrict_GivenBreak_WhenPausedAndStopped_ThenBehavesAsUsual(t *testing.T) {
+	c, clock, _ := strictCycle()
+	clock.Advance(12*time.Minute + 30*time.Second)
+	c.Tick()
+
+	c.Pause()
+	if !c.IsPaused() {
+		t.Fatal("expected a break to pause in strict mode")
+	}
+	c.Stop()
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatal("expected a break to stop at once in strict mode")
+	}
+}
@@ -0,0 +1,105 @@
+package gopomodoro
+
+import (
+	"slices"
+	"strings"
+)
+
+// Task labels what the pomodoros of a cycle are spent on.
+type Task struct {
+	Name string
+	Tags []string
+
+	// Estimate is the number of pomodoros the task is expected to take; zero
+	// means no estimate. Actual counts the pomodoros completed against it so
+	// far and goes up by one whenever a pomodoro labelled with the task
+	// completes or is skipped.
+	Estimate int
+	Actual   int
+}
+
+// IsZero reports whether no task is set.
+func (t Task) IsZero() bool {
+	return t.Name == "" && len(t.Tags) == 0 && t.Estimate == 0 && t.Actual == 0
+}
+
+// Overruns reports whether another pomodoro would take the task past its
+// estimate.
+func (t Task) Overruns() bool {
+	return t.Estimate > 0 && t.Actual >= t.Estimate
+}
+
+// String returns the name followed by the tags, e.g. "Write report #docs".
+func (t Task) String() string {
+	parts := make([]string, 0, 1+len(t.Tags))
+	if t.Name != "" {
+		parts = append(parts, t.Name)
+	}
+	for _, tag := range t.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// clone returns a copy of t that shares no memory with it, so callers
+// cannot change the task of the cycle through a slice they hold.
+func (t Task) clone() Task {
+	if t.Tags != nil {
+		t.Tags = append([]string(nil), t.Tags...)
+	}
+	return t
+}
+
+// SetTask labels the current pomodoro, if any, and the ones that follow with
+// t. The task is kept across breaks and Stop until it is changed; the zero
+// Task clears it. SetTask emits TaskChanged unless t equals the current task.
+func (c *Cycle) SetTask(t Task) {
+	c.mu.Lock()
+	c.setTask(t)
+	c.mu.Unlock()
+	c.deliver()
+}
+
+func (c *Cycle) setTask(t Task) {
+	if t.Name == c.task.Name && slices.Equal(t.Tags, c.task.Tags) && t.Estimate == c.task.Estimate && t.Actual == c.task.Actual {
+		return
+	}
+	c.task = t.clone()
+	c.emit(TaskChanged)
+	c.notifyStateChanged()
+}
+
+// StartTask labels the cycle with t and starts a pomodoro, unless a phase
+// other than a pomodoro waiting for Start is already under way.
+func (c *Cycle) StartTask(t Task) {
+	if c.Ticker == nil {
+		panic("Cycle.StartTask called without Ticker")
+	}
+	c.mu.Lock()
+	c.setTask(t)
+	if c.State == Idle || c.waiting && c.State == Pomodoro {
+		c.start()
+	}
+	c.mu.Unlock()
+	c.deliver()
+}
+
+// countPomodoro counts a completed pomodoro against the task and emits
+// Overrun once the task has used up its estimate. Must be called with mu
+// held, before leaving the pomodoro.
+func (c *Cycle) countPomodoro() {
+	if c.task.IsZero() {
+		return
+	}
+	c.task.Actual++
+	if c.task.Overruns() {
+		c.emit(Overrun)
+	}
+}
+
+// Task returns the task the cycle is labelled with.
+func (c *Cycle) Task() Task {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.task.clone()
+}
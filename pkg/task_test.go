@@ -0,0 +1,162 @@
+package gopomodoro_test
+
+import (
+	"reflect"
+	"testing"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+)
+
+func TestTask_GivenNameAndTags_WhenFormatted_ThenTagsArePrefixed(t *testing.T) {
+	task := gopomodoro.Task{Name: "Write report", Tags: []string{"docs", "q3"}}
+
+	if got := task.String(); got != "Write report #docs #q3" {
+		t.Fatalf("expected %q, got %q", "Write report #docs #q3", got)
+	}
+}
+
+func TestSetTask_GivenRunningPomodoro_WhenSet_ThenEmitsTaskChangedAndLabelsEvents(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	observer := &pomotest.MockObserver{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}, Observer: observer}
+	c.Subscribe(subscriber)
+	c.Start()
+	task := gopomodoro.Task{Name: "Write report", Tags: []string{"docs"}}
+
+	c.SetTask(task)
+	pomotest.CompleteCycle(c)
+
+	changed, ok := subscriber.Last(gopomodoro.TaskChanged)
+	if !ok || !reflect.DeepEqual(changed.Task, task) {
+		t.Fatalf("expected TaskChanged with %+v, got %+v", task, changed)
+	}
+	completed, _ := subscriber.Last(gopomodoro.PhaseCompleted)
+	if !reflect.DeepEqual(completed.Task, task) {
+		t.Fatalf("expected completed pomodoro labelled %+v, got %+v", task, completed.Task)
+	}
+	if got := c.Snapshot().Task; got.Name != task.Name || !reflect.DeepEqual(got.Tags, task.Tags) {
+		t.Fatalf("expected task to be kept during the short break, got %+v", got)
+	}
+}
+
+func TestSetTask_GivenSameTask_WhenSetAgain_ThenNoEvent(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.SetTask(gopomodoro.Task{Name: "Email"})
+	c.Subscribe(subscriber)
+
+	c.SetTask(gopomodoro.Task{Name: "Email"})
+
+	if len(subscriber.Types()) != 0 {
+		t.Fatalf("expected no events, got %v", subscriber.Types())
+	}
+}
+
+func TestSetTask_GivenTagsSlice_WhenCallerChangesIt_ThenCycleTaskIsUnchanged(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	tags := []string{"docs"}
+	c.SetTask(gopomodoro.Task{Name: "Write report", Tags: tags})
+
+	tags[0] = "changed"
+
+	if got := c.Task().Tags[0]; got != "docs" {
+		t.Fatalf("expected tag %q, got %q", "docs", got)
+	}
+}
+
+func TestSetTask_GivenTask_WhenStopped_ThenTaskIsKept(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.SetTask(gopomodoro.Task{Name: "Write report"})
+	c.Start()
+
+	c.Stop()
+
+	if c.Task().Name != "Write report" {
+		t.Fatalf("expected task to survive Stop, got %+v", c.Task())
+	}
+}
+
+func TestRestore_GivenIdleCheckpointWithTask_WhenRestored_ThenTaskIsRestored(t *testing.T) {
+	previous := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	previous.SetTask(gopomodoro.Task{Name: "Write report"})
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+
+	c.Restore(previous.Checkpoint())
+
+	if c.Task().Name != "Write report" || !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected idle cycle labelled Write report, got %+v", c.Snapshot())
+	}
+}
+
+func TestStartTask_GivenIdleCycle_WhenStarted_ThenPomodoroRunsAgainstTask(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+
+	c.StartTask(gopomodoro.Task{Name: "Write report"})
+
+	started, ok := subscriber.Last(gopomodoro.PhaseStarted)
+	if !ok || started.Phase != gopomodoro.Pomodoro || started.Task.Name != "Write report" {
+		t.Fatalf("expected pomodoro started for Write report, got %+v", started)
+	}
+}
+
+func TestStartTask_GivenShortBreak_WhenStarted_ThenBreakContinuesWithNewTask(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+	pomotest.CompleteCycle(c)
+
+	c.StartTask(gopomodoro.Task{Name: "Review PR"})
+
+	if s := c.Snapshot(); s.State != gopomodoro.ShortBreak || s.Task.Name != "Review PR" {
+		t.Fatalf("expected short break labelled Review PR, got %+v", s)
+	}
+}
+
+func TestTask_GivenEstimate_WhenPomodorosComplete_ThenActualCountsAndOverrunIsEmitted(t *testing.T) {
+	subscriber := &pomotest.MockSubscriber{}
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Subscribe(subscriber)
+	c.StartTask(gopomodoro.Task{Name: "Write report", Estimate: 2})
+
+	pomotest.CompleteCycle(c)
+	if _, ok := subscriber.Last(gopomodoro.Overrun); ok || c.Task().Actual != 1 {
+		t.Fatalf("expected 1 pomodoro and no overrun yet, got %+v", c.Task())
+	}
+	pomotest.CompleteCycle(c)
+	pomotest.CompleteCycle(c)
+
+	overrun, ok := subscriber.First(gopomodoro.Overrun)
+	if !ok || overrun.Task.Actual != 2 || overrun.Phase != gopomodoro.Pomodoro {
+		t.Fatalf("expected overrun once the second pomodoro counted, got %+v", overrun)
+	}
+	if !c.Task().Overruns() {
+		t.Fatalf("expected the next pomodoro to overrun, got %#v", c.Task())
+	}
+}
+
+func TestTask_GivenRunningPomodoro_WhenSkipped_ThenItDoesNotCount(t *testing.T) {
+	c, _, subscriber := pomotest.NewCycle(nil)
+	c.StartTask(gopomodoro.Task{Name: "Write report", Estimate: 1})
+
+	c.Skip()
+
+	if actual := c.Task().Actual; actual != 0 {
+		t.Fatalf("expected a skipped pomodoro not to count, got %d", actual)
+	}
+	if _, ok := subscriber.Last(gopomodoro.Overrun); ok {
+		t.Fatal("expected no overrun")
+	}
+}
+
+func TestTask_GivenNoTask_WhenPomodoroCompletes_ThenNothingIsCounted(t *testing.T) {
+	c := &gopomodoro.Cycle{Ticker: &pomotest.MockTicker{}}
+	c.Start()
+
+	pomotest.CompleteCycle(c)
+
+	if !c.Task().IsZero() {
+		t.Fatalf("expected no task, got %+v", c.Task())
+	}
+}
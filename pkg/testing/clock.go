@@ -0,0 +1,27 @@
+package testing
+
+import (
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// MockClock is a manually advanced clock for deterministic tests.
+type MockClock struct {
+	now time.Time
+}
+
+func NewMockClock(now time.Time) *MockClock {
+	return &MockClock{now: now}
+}
+
+func (m *MockClock) Now() time.Time {
+	return m.now
+}
+
+// Advance moves the clock forward by d.
+func (m *MockClock) Advance(d time.Duration) {
+	m.now = m.now.Add(d)
+}
+
+var _ gopomodoro.Clock = (*MockClock)(nil)
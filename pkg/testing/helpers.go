@@ -1,11 +1,33 @@
 package testing
 
-import gopomodoro "github.com/co0p/gopomodoro/pkg"
+import (
+	"time"
 
-// CompleteCycle advances the timer through the full duration of the current state.
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// StartTime is Monday 5 January 2026 at 09:00 UTC, the time NewCycle's
+// clock starts at.
+var StartTime = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+// NewCycle returns an idle cycle on a MockTicker and a MockClock set to
+// StartTime, with a MockSubscriber subscribed. configure, if not nil, sets
+// further fields, or replaces the ticker, before the subscriber is added.
+func NewCycle(configure func(c *gopomodoro.Cycle)) (*gopomodoro.Cycle, *MockClock, *MockSubscriber) {
+	clock := NewMockClock(StartTime)
+	c := &gopomodoro.Cycle{Ticker: &MockTicker{}, Clock: clock}
+	if configure != nil {
+		configure(c)
+	}
+	subscriber := &MockSubscriber{}
+	c.Subscribe(subscriber)
+	return c, clock, subscriber
+}
+
+// CompleteCycle advances the timer through the full configured duration of the current state.
 func CompleteCycle(c *gopomodoro.Cycle) {
-	duration := int(c.State)
-	for i := 0; i < duration; i++ {
+	minutes := int(c.PhaseDuration(c.State) / time.Minute)
+	for i := 0; i < minutes; i++ {
 		c.AdvanceMinute()
 	}
 }
@@ -0,0 +1,42 @@
+package testing
+
+import (
+	"sync"
+	"time"
+)
+
+// MockIdleSource reports whatever idle time it was last given.
+type MockIdleSource struct {
+	mu    sync.Mutex
+	since time.Time
+	err   error
+	calls int
+}
+
+// SetIdle makes the user idle since since; the zero time makes them active.
+func (m *MockIdleSource) SetIdle(since time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.since, m.err = since, nil
+}
+
+// SetError makes IdleSince fail with err.
+func (m *MockIdleSource) SetError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+func (m *MockIdleSource) IdleSince() (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	return m.since, m.err
+}
+
+// Calls returns how often IdleSince was called.
+func (m *MockIdleSource) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
@@ -1,9 +1,14 @@
 package testing
 
+import "sync"
+
 type MockNotifier struct {
+	mu              sync.Mutex
 	NotifyCallCount int
 }
 
 func (m *MockNotifier) Notify() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.NotifyCallCount++
 }
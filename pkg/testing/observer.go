@@ -1,11 +1,37 @@
 package testing
 
-import gopomodoro "github.com/co0p/gopomodoro/pkg"
+import (
+	"sync"
+	"time"
 
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// MockObserver records state changes. It is safe for use from the cycle's
+// tick goroutine; read StateChanges after WaitForStateChanges in that case.
 type MockObserver struct {
+	mu           sync.Mutex
 	StateChanges []gopomodoro.CycleState
 }
 
 func (m *MockObserver) OnStateChanged(state gopomodoro.CycleState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.StateChanges = append(m.StateChanges, state)
 }
+
+// WaitForStateChanges blocks until at least n state changes were recorded
+// or a second has passed. It reports whether n changes arrived.
+func (m *MockObserver) WaitForStateChanges(n int) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		m.mu.Lock()
+		got := len(m.StateChanges)
+		m.mu.Unlock()
+		if got >= n {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package testing
+
+import (
+	"sync"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+// MockSubscriber records every event it receives.
+type MockSubscriber struct {
+	mu     sync.Mutex
+	Events []gopomodoro.Event
+}
+
+func (m *MockSubscriber) OnEvent(e gopomodoro.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Events = append(m.Events, e)
+}
+
+// Types returns the types of the recorded events, skipping Ticked events.
+func (m *MockSubscriber) Types() []gopomodoro.EventType {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var types []gopomodoro.EventType
+	for _, e := range m.Events {
+		if e.Type != gopomodoro.Ticked {
+			types = append(types, e.Type)
+		}
+	}
+	return types
+}
+
+// First returns the earliest event of type t and whether one was recorded.
+func (m *MockSubscriber) First(t gopomodoro.EventType) (gopomodoro.Event, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.Events {
+		if e.Type == t {
+			return e, true
+		}
+	}
+	return gopomodoro.Event{}, false
+}
+
+// Last returns the most recent event of type t and whether one was recorded.
+func (m *MockSubscriber) Last(t gopomodoro.EventType) (gopomodoro.Event, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.Events) - 1; i >= 0; i-- {
+		if m.Events[i].Type == t {
+			return m.Events[i], true
+		}
+	}
+	return gopomodoro.Event{}, false
+}
+
+// Count returns the number of recorded events of type t.
+func (m *MockSubscriber) Count(t gopomodoro.EventType) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, e := range m.Events {
+		if e.Type == t {
+			n++
+		}
+	}
+	return n
+}
+
+var _ gopomodoro.EventSubscriber = (*MockSubscriber)(nil)
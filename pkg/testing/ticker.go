@@ -21,6 +21,11 @@ func (m *MockTicker) Stop() {
 	m.started = false
 }
 
+// Started reports whether the ticker is currently running.
+func (m *MockTicker) Started() bool {
+	return m.started
+}
+
 func (m *MockTicker) OnTick() <-chan struct{} {
 	return m.tickChan
 }
@@ -1,11 +1,19 @@
 package ticker
 
 import (
+	"sync"
 	"time"
 )
 
+// Interval is how often the ticker fires. The cycle derives the remaining
+// time from its phase deadline, so ticks only need to be frequent enough
+// for a second-precision display.
+const Interval = time.Second
+
 // Ticker implements gopomodoro.Ticker using time.Ticker.
+// Start and Stop may be called from different goroutines.
 type Ticker struct {
+	mu       sync.Mutex
 	ticker   *time.Ticker
 	tickChan chan struct{}
 	stopChan chan struct{}
@@ -14,28 +22,44 @@ type Ticker struct {
 func New() *Ticker {
 	return &Ticker{
 		tickChan: make(chan struct{}),
-		stopChan: make(chan struct{}),
 	}
 }
 
+// Start begins delivering ticks. Calling Start on a running ticker is a no-op.
 func (t *Ticker) Start() {
-	t.ticker = time.NewTicker(1 * time.Minute)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ticker != nil {
+		return
+	}
+	ticker := time.NewTicker(Interval)
+	stop := make(chan struct{})
+	t.ticker, t.stopChan = ticker, stop
 	go func() {
 		for {
 			select {
-			case <-t.ticker.C:
-				t.tickChan <- struct{}{}
-			case <-t.stopChan:
+			case <-ticker.C:
+				// Never block on an unread tick once stopped.
+				select {
+				case t.tickChan <- struct{}{}:
+				case <-stop:
+					return
+				}
+			case <-stop:
 				return
 			}
 		}
 	}()
 }
 
+// Stop halts the ticker. Calling Stop on a stopped ticker is a no-op.
 func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if t.ticker != nil {
 		t.ticker.Stop()
-		t.stopChan <- struct{}{}
+		close(t.stopChan)
+		t.ticker = nil
 	}
 }
 
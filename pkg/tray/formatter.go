@@ -14,18 +14,152 @@ func (f *Formatter) Format(state gopomodoro.CycleState, remaining time.Duration)
 	const coffeeIcon = "☕"
 	const longBreakIcon = "🌴"
 
-	minutes := fmt.Sprintf("%d", int(remaining.Minutes()))
+	timer := formatTimer(remaining)
 
 	switch state {
 	case gopomodoro.Pomodoro:
-		return tomatoIcon + " " + minutes + "m"
+		return tomatoIcon + " " + timer
 	case gopomodoro.ShortBreak:
-		return coffeeIcon + " " + minutes + "m"
+		return coffeeIcon + " " + timer
 	case gopomodoro.LongBreak:
-		return longBreakIcon + " " + minutes + "m"
+		return longBreakIcon + " " + timer
 	case gopomodoro.Idle:
 		fallthrough
 	default:
 		return tomatoIcon
 	}
 }
+
+// formatTimer renders the time left in whole minutes, or as mm:ss during
+// the last minute.
+func formatTimer(remaining time.Duration) string {
+	if remaining < time.Minute {
+		return fmt.Sprintf("00:%02d", int(remaining.Seconds()))
+	}
+	return fmt.Sprintf("%dm", int(remaining.Minutes()))
+}
+
+const warningIcon = "⚠"
+
+const stopIcon = "⏹"
+
+// FormatSnapshot renders the tray title for a cycle snapshot, marking
+// paused and waiting phases and a pending stop, showing the rest owed before
+// the next pomodoro, progress towards the pomodoro goal and warning when the
+// next pomodoro would overrun the task's estimate.
+func (f *Formatter) FormatSnapshot(s gopomodoro.Snapshot) string {
+	var title string
+	switch {
+	case s.Paused:
+		title = f.FormatPaused(s.State, s.Remaining)
+	case s.Waiting:
+		title = f.FormatWaiting(s.State, s.Remaining)
+	default:
+		title = f.Format(s.State, s.Remaining)
+	}
+	if s.StopPending {
+		title += " " + stopIcon
+	}
+	if s.RestLeft > 0 && (s.State == gopomodoro.Idle || s.Waiting && s.State == gopomodoro.Pomodoro) {
+		title += " · rest " + formatTimer(s.RestLeft)
+	}
+	if progress := f.FormatProgress(s.Progress); progress != "" {
+		title += " · " + progress
+	}
+	if s.Task.Overruns() {
+		title += " " + warningIcon
+	}
+	return title
+}
+
+// FormatProgress renders the pomodoros completed towards the daily goal,
+// e.g. "6/10", or towards the weekly goal if there is no daily one. It is
+// empty without goals.
+func (f *Formatter) FormatProgress(p gopomodoro.Progress) string {
+	switch {
+	case p.Goals.Daily > 0:
+		return fmt.Sprintf("%d/%d", p.Today, p.Goals.Daily)
+	case p.Goals.Weekly > 0:
+		return fmt.Sprintf("%d/%d", p.Week, p.Goals.Weekly)
+	default:
+		return ""
+	}
+}
+
+// FormatWaiting renders a phase that waits for Start: the regular display
+// of the upcoming phase prefixed with a play icon.
+func (f *Formatter) FormatWaiting(state gopomodoro.CycleState, remaining time.Duration) string {
+	const playIcon = "▶"
+
+	return playIcon + " " + f.Format(state, remaining)
+}
+
+// FormatPaused renders a paused phase: the regular display prefixed with a pause icon.
+func (f *Formatter) FormatPaused(state gopomodoro.CycleState, remaining time.Duration) string {
+	const pauseIcon = "⏸"
+
+	return pauseIcon + " " + f.Format(state, remaining)
+}
+
+// FormatTooltip renders the tray tooltip, naming the task the cycle is
+// labelled with, if any, its progress against the estimate, the progress
+// towards the daily and weekly goals, the active profile, a pending stop,
+// the rest owed, a stop awaiting confirmation, why the last pomodoro was
+// refused, shortened or warned about and idle time that can be discarded.
+func (f *Formatter) FormatTooltip(s gopomodoro.Snapshot) string {
+	const appName = "GoPomodoro"
+
+	tooltip := appName
+	if s.StopPending {
+		tooltip += " · stopping after this phase"
+	}
+	if s.RestLeft > 0 {
+		tooltip += " · rest " + formatTimer(s.RestLeft) + " more before the next pomodoro"
+	}
+	if s.AbandonPending {
+		tooltip += " · choose Really Abandon? to void this pomodoro"
+	}
+	if s.Notice != "" {
+		tooltip += " · " + warningIcon + " " + s.Notice
+	}
+	if s.IdleTime > 0 {
+		tooltip += fmt.Sprintf(" · paused after %dm idle", int(s.IdleTime.Round(time.Minute).Minutes()))
+	}
+	if !s.Task.IsZero() {
+		tooltip += " · " + s.Task.String()
+		if s.Task.Estimate > 0 {
+			tooltip += fmt.Sprintf(" · %d/%d", s.Task.Actual, s.Task.Estimate)
+		}
+		if s.Task.Overruns() {
+			tooltip += " " + warningIcon + " estimate used up"
+		}
+	}
+	if goal := s.Progress.Goals.Daily; goal > 0 {
+		tooltip += fmt.Sprintf(" · today %d/%d", s.Progress.Today, goal)
+	}
+	if goal := s.Progress.Goals.Weekly; goal > 0 {
+		tooltip += fmt.Sprintf(" · this week %d/%d", s.Progress.Week, goal)
+	}
+	if s.Profile != "" {
+		tooltip += " · " + s.Profile
+	}
+	if s.PendingProfile != "" {
+		tooltip += " → " + s.PendingProfile + " next phase"
+	}
+	return tooltip
+}
+
+// FormatProfile renders the Profile menu entry for the profile called name,
+// marking it if the cycle switches to it when the current phase ends.
+func (f *Formatter) FormatProfile(name string, s gopomodoro.Snapshot) string {
+	if name == s.PendingProfile {
+		return name + " (next phase)"
+	}
+	return name
+}
+
+// FormatDiscardIdle renders the menu entry that gives the idle time before
+// an automatic pause back to the pomodoro, e.g. "Discard 5m Idle Time".
+func (f *Formatter) FormatDiscardIdle(s gopomodoro.Snapshot) string {
+	return fmt.Sprintf("Discard %dm Idle Time", int(s.IdleTime.Round(time.Minute).Minutes()))
+}
@@ -51,3 +51,222 @@ func TestTray_GivenLongBreakRunning_WhenDisplayed_ThenShowsPalmTreeIconWithMinut
 		t.Fatalf("expected %q, got %q", expected, result)
 	}
 }
+
+func TestTray_GivenPomodoroPaused_WhenDisplayed_ThenShowsPauseIndicator(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatPaused(gopomodoro.Pomodoro, 12*time.Minute)
+
+	expected := "⏸ 🍅 12m"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenLastMinuteOfPomodoro_WhenDisplayed_ThenShowsSeconds(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.Format(gopomodoro.Pomodoro, 42*time.Second)
+
+	expected := "🍅 00:42"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenWaitingForShortBreak_WhenDisplayed_ThenShowsPlayIndicator(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatSnapshot(gopomodoro.Snapshot{
+		State:     gopomodoro.ShortBreak,
+		Remaining: 5 * time.Minute,
+		Waiting:   true,
+	})
+
+	expected := "▶ ☕ 5m"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenTask_WhenTooltipDisplayed_ThenShowsTaskAndTags(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatTooltip(gopomodoro.Snapshot{
+		State: gopomodoro.ShortBreak,
+		Task:  gopomodoro.Task{Name: "Write report", Tags: []string{"docs"}},
+	})
+
+	expected := "GoPomodoro · Write report #docs"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenNoTask_WhenTooltipDisplayed_ThenShowsAppName(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatTooltip(gopomodoro.Snapshot{State: gopomodoro.Pomodoro})
+
+	expected := "GoPomodoro"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenTaskAtItsEstimate_WhenDisplayed_ThenWarnsOfOverrun(t *testing.T) {
+	formatter := tray.Formatter{}
+	snapshot := gopomodoro.Snapshot{
+		State:     gopomodoro.ShortBreak,
+		Remaining: 4 * time.Minute,
+		Task:      gopomodoro.Task{Name: "Write report", Estimate: 3, Actual: 3},
+	}
+
+	title := formatter.FormatSnapshot(snapshot)
+	tooltip := formatter.FormatTooltip(snapshot)
+
+	if expected := "☕ 4m ⚠"; title != expected {
+		t.Fatalf("expected title %q, got %q", expected, title)
+	}
+	if expected := "GoPomodoro · Write report · 3/3 ⚠ estimate used up"; tooltip != expected {
+		t.Fatalf("expected tooltip %q, got %q", expected, tooltip)
+	}
+}
+
+func TestTray_GivenTaskWithinEstimate_WhenTooltipDisplayed_ThenShowsProgress(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatTooltip(gopomodoro.Snapshot{
+		Task: gopomodoro.Task{Name: "Write report", Estimate: 3, Actual: 1},
+	})
+
+	expected := "GoPomodoro · Write report · 1/3"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenDailyGoal_WhenDisplayed_ThenShowsProgress(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatSnapshot(gopomodoro.Snapshot{
+		State:     gopomodoro.Pomodoro,
+		Remaining: 12 * time.Minute,
+		Progress:  gopomodoro.Progress{Today: 6, Week: 20, Goals: gopomodoro.Goals{Daily: 10, Weekly: 40}},
+	})
+
+	expected := "🍅 12m · 6/10"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenWeeklyGoalOnly_WhenIdle_ThenShowsWeeklyProgress(t *testing.T) {
+	formatter := tray.Formatter{}
+	snapshot := gopomodoro.Snapshot{
+		Progress: gopomodoro.Progress{Today: 3, Week: 20, Goals: gopomodoro.Goals{Weekly: 40}},
+	}
+
+	title := formatter.FormatSnapshot(snapshot)
+	tooltip := formatter.FormatTooltip(snapshot)
+
+	if expected := "🍅 · 20/40"; title != expected {
+		t.Fatalf("expected title %q, got %q", expected, title)
+	}
+	if expected := "GoPomodoro · this week 20/40"; tooltip != expected {
+		t.Fatalf("expected tooltip %q, got %q", expected, tooltip)
+	}
+}
+
+func TestTray_GivenPendingProfile_WhenDisplayed_ThenMarksSwitchAtNextPhase(t *testing.T) {
+	formatter := tray.Formatter{}
+	snapshot := gopomodoro.Snapshot{State: gopomodoro.Pomodoro, Profile: "classic", PendingProfile: "deep-work"}
+
+	tooltip := formatter.FormatTooltip(snapshot)
+	pending := formatter.FormatProfile("deep-work", snapshot)
+	active := formatter.FormatProfile("classic", snapshot)
+
+	if expected := "GoPomodoro · classic → deep-work next phase"; tooltip != expected {
+		t.Fatalf("expected tooltip %q, got %q", expected, tooltip)
+	}
+	if pending != "deep-work (next phase)" || active != "classic" {
+		t.Fatalf("expected only deep-work to be marked, got %q and %q", pending, active)
+	}
+}
+
+func TestTray_GivenStopPending_WhenDisplayed_ThenMarksStop(t *testing.T) {
+	formatter := tray.Formatter{}
+	snapshot := gopomodoro.Snapshot{State: gopomodoro.Pomodoro, Remaining: 7 * time.Minute, StopPending: true}
+
+	title := formatter.FormatSnapshot(snapshot)
+	tooltip := formatter.FormatTooltip(snapshot)
+
+	if expected := "🍅 7m ⏹"; title != expected {
+		t.Fatalf("expected title %q, got %q", expected, title)
+	}
+	if expected := "GoPomodoro · stopping after this phase"; tooltip != expected {
+		t.Fatalf("expected tooltip %q, got %q", expected, tooltip)
+	}
+}
+
+func TestTray_GivenNotice_WhenTooltipDisplayed_ThenShowsIt(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatTooltip(gopomodoro.Snapshot{State: gopomodoro.Pomodoro, Notice: "shortened to 15m before Stand-up at 10:00"})
+
+	expected := "GoPomodoro · ⚠ shortened to 15m before Stand-up at 10:00"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenPausedWhileIdle_WhenDisplayed_ThenOffersToDiscard(t *testing.T) {
+	formatter := tray.Formatter{}
+	snapshot := gopomodoro.Snapshot{State: gopomodoro.Pomodoro, Paused: true, IdleTime: 5*time.Minute + 10*time.Second}
+
+	tooltip := formatter.FormatTooltip(snapshot)
+	item := formatter.FormatDiscardIdle(snapshot)
+
+	if expected := "GoPomodoro · paused after 5m idle"; tooltip != expected {
+		t.Fatalf("expected tooltip %q, got %q", expected, tooltip)
+	}
+	if expected := "Discard 5m Idle Time"; item != expected {
+		t.Fatalf("expected menu item %q, got %q", expected, item)
+	}
+}
+
+func TestTray_GivenAbandonPending_WhenTooltipDisplayed_ThenAsksForConfirmation(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	result := formatter.FormatTooltip(gopomodoro.Snapshot{State: gopomodoro.Pomodoro, AbandonPending: true})
+
+	expected := "GoPomodoro · choose Really Abandon? to void this pomodoro"
+	if result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTray_GivenRestOwed_WhenDisplayed_ThenShowsRestLeft(t *testing.T) {
+	formatter := tray.Formatter{}
+	snapshot := gopomodoro.Snapshot{State: gopomodoro.Idle, RestLeft: 2*time.Minute + 30*time.Second}
+
+	title := formatter.FormatSnapshot(snapshot)
+	tooltip := formatter.FormatTooltip(snapshot)
+
+	if expected := "🍅 · rest 2m"; title != expected {
+		t.Fatalf("expected title %q, got %q", expected, title)
+	}
+	if expected := "GoPomodoro · rest 2m more before the next pomodoro"; tooltip != expected {
+		t.Fatalf("expected tooltip %q, got %q", expected, tooltip)
+	}
+}
+
+func TestTray_GivenRestOwedDuringBreak_WhenDisplayed_ThenTitleShowsBreak(t *testing.T) {
+	formatter := tray.Formatter{}
+
+	title := formatter.FormatSnapshot(gopomodoro.Snapshot{State: gopomodoro.ShortBreak, Remaining: 4 * time.Minute, RestLeft: 2 * time.Minute})
+
+	if expected := "☕ 4m"; title != expected {
+		t.Fatalf("expected %q, got %q", expected, title)
+	}
+}
@@ -1,13 +1,71 @@
 package tray
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/inventory"
 	"github.com/getlantern/systray"
 )
 
+// maxTodayItems is the number of tasks the Today menu can list.
+const maxTodayItems = 12
+
+// TaskList provides the activities offered in the Today menu. Today is
+// called on every render, so it should only re-read its source when that
+// changed, as inventory.Store does by checking the file's modification time.
+type TaskList interface {
+	Today() ([]inventory.Item, error)
+}
+
 // Tray implements the system tray using getlantern/systray.
 type Tray struct {
+	// Tasks is optional; without it the Today menu is hidden.
+	Tasks TaskList
+
+	// Profiles are offered in the Profile menu, which is hidden without
+	// them, and passed to SetProfile as they are. Set before Run.
+	Profiles []gopomodoro.Profile
+
 	cycle *gopomodoro.Cycle
+
+	// ready is set once the menu exists. State changes before that, e.g.
+	// from restoring a previous session, are rendered by onReady.
+	ready atomic.Bool
+
+	mTask  *systray.MenuItem
+	mStart *systray.MenuItem
+	mPause *systray.MenuItem
+	mSkip  *systray.MenuItem
+
+	mInterruption *systray.MenuItem
+	mInternal     *systray.MenuItem
+	mExternal     *systray.MenuItem
+
+	mExtend  *systray.MenuItem
+	mExtend1 *systray.MenuItem
+	mExtend5 *systray.MenuItem
+
+	mStopAfter   *systray.MenuItem
+	mDiscardIdle *systray.MenuItem
+	mAbandon     *systray.MenuItem
+
+	mToday     *systray.MenuItem
+	todayItems [maxTodayItems]menuEntry
+
+	mProfile     *systray.MenuItem
+	profileItems []menuEntry
+
+	// renderMu serializes renders, which compare the menu entries with what
+	// they showed last.
+	renderMu sync.Mutex
+
+	// today holds the tasks behind todayItems, guarded by todayMu.
+	todayMu sync.Mutex
+	today   []inventory.Item
 }
 
 // New creates a new Tray with the given cycle.
@@ -17,8 +75,197 @@ func New(c *gopomodoro.Cycle) *Tray {
 
 // OnStateChanged updates the tray display when the cycle state changes.
 func (t *Tray) OnStateChanged(state gopomodoro.CycleState) {
+	if !t.ready.Load() {
+		return
+	}
+	t.render()
+}
+
+// render shows the current state of the cycle in the title and menu.
+func (t *Tray) render() {
+	t.renderMu.Lock()
+	defer t.renderMu.Unlock()
+	snapshot := t.cycle.Snapshot()
 	formatter := &Formatter{}
-	systray.SetTitle(formatter.Format(state, t.cycle.Remaining()))
+	systray.SetTitle(formatter.FormatSnapshot(snapshot))
+	systray.SetTooltip(formatter.FormatTooltip(snapshot))
+	t.updateMenu(snapshot)
+}
+
+// updateMenu shows the current task, names the Start and Pause/Resume menu
+// items after what they will do, disables Start while a pomodoro may not
+// start and enables the items that only apply to a running cycle.
+func (t *Tray) updateMenu(snapshot gopomodoro.Snapshot) {
+	if snapshot.Task.IsZero() {
+		t.mTask.Hide()
+	} else {
+		t.mTask.SetTitle(snapshot.Task.String())
+		t.mTask.Show()
+	}
+
+	switch {
+	case snapshot.Waiting && snapshot.State == gopomodoro.Pomodoro:
+		t.mStart.SetTitle("Start Pomodoro")
+	case snapshot.Waiting:
+		t.mStart.SetTitle("Start Break")
+	default:
+		t.mStart.SetTitle("Start")
+	}
+	blocked := t.cycle.StartBlocked()
+	if blocked != nil {
+		t.mStart.SetTooltip("Cannot start: " + blocked.Error())
+	} else {
+		t.mStart.SetTooltip("Start Pomodoro")
+	}
+	setEnabled(t.mStart, blocked == nil)
+	if snapshot.Paused {
+		t.mPause.SetTitle("Resume")
+		t.mPause.SetTooltip("Resume Pomodoro")
+	} else {
+		t.mPause.SetTitle("Pause")
+		t.mPause.SetTooltip("Pause Pomodoro")
+	}
+	running := snapshot.State != gopomodoro.Idle && !snapshot.Waiting
+	// A started pomodoro cannot be paused or skipped in strict mode.
+	strict := t.cycle.Strict && snapshot.State == gopomodoro.Pomodoro && !snapshot.Waiting
+	if strict && !snapshot.Paused {
+		t.mPause.SetTooltip("A pomodoro cannot be paused in strict mode")
+	}
+	setEnabled(t.mPause, running && (!strict || snapshot.Paused))
+	setEnabled(t.mSkip, snapshot.State != gopomodoro.Idle && !strict)
+
+	t.mInternal.SetTitle(fmt.Sprintf("Internal (%d)", snapshot.Interruptions.Internal))
+	t.mExternal.SetTitle(fmt.Sprintf("External (%d)", snapshot.Interruptions.External))
+	setEnabled(t.mInterruption, running && snapshot.State == gopomodoro.Pomodoro)
+
+	room := t.cycle.ExtensionLimit(snapshot.State) - snapshot.Extension
+	setEnabled(t.mExtend, running && room >= time.Minute)
+	setEnabled(t.mExtend1, room >= time.Minute)
+	setEnabled(t.mExtend5, room >= 5*time.Minute)
+
+	if snapshot.StopPending {
+		t.mStopAfter.Check()
+	} else {
+		t.mStopAfter.Uncheck()
+	}
+	setEnabled(t.mStopAfter, running)
+
+	if snapshot.AbandonPending {
+		t.mAbandon.Show()
+	} else {
+		t.mAbandon.Hide()
+	}
+
+	if snapshot.IdleTime > 0 {
+		t.mDiscardIdle.SetTitle((&Formatter{}).FormatDiscardIdle(snapshot))
+		t.mDiscardIdle.Show()
+	} else {
+		t.mDiscardIdle.Hide()
+	}
+
+	t.updateToday(snapshot.Task)
+	t.updateProfiles(snapshot)
+}
+
+// updateProfiles checks the active profile in the Profile menu and marks the
+// one the cycle switches to when the current phase ends.
+func (t *Tray) updateProfiles(snapshot gopomodoro.Snapshot) {
+	formatter := &Formatter{}
+	for i := range t.profileItems {
+		name := t.Profiles[i].Name
+		t.profileItems[i].show(formatter.FormatProfile(name, snapshot), name == snapshot.Profile)
+	}
+}
+
+// updateToday lists today's tasks in the Today menu, checking the one the
+// cycle is labelled with.
+func (t *Tray) updateToday(current gopomodoro.Task) {
+	if t.Tasks == nil {
+		return
+	}
+	items, err := t.Tasks.Today()
+	if err != nil {
+		items = nil
+	}
+	if len(items) > maxTodayItems {
+		items = items[:maxTodayItems]
+	}
+	t.todayMu.Lock()
+	t.today = items
+	t.todayMu.Unlock()
+
+	for i := range t.todayItems {
+		if i >= len(items) {
+			t.todayItems[i].hide()
+			continue
+		}
+		title := items[i].Task().String()
+		if items[i].Estimate > 0 {
+			title += fmt.Sprintf(" (%d/%d)", items[i].Actual, items[i].Estimate)
+		}
+		if items[i].Section == inventory.Urgent {
+			title = "! " + title
+		}
+		t.todayItems[i].show(title, items[i].Name == current.Name)
+	}
+	setEnabled(t.mToday, len(items) > 0)
+}
+
+// startToday starts a pomodoro against the i-th task of the Today menu.
+func (t *Tray) startToday(i int) {
+	t.todayMu.Lock()
+	if i >= len(t.today) {
+		t.todayMu.Unlock()
+		return
+	}
+	item := t.today[i]
+	t.todayMu.Unlock()
+	t.cycle.StartTask(item.Task())
+}
+
+// menuEntry is a menu item together with what it shows, so that renders,
+// which happen on every tick, only touch the items that changed.
+type menuEntry struct {
+	item    *systray.MenuItem
+	title   string
+	checked bool
+	hidden  bool
+}
+
+// show shows the entry with the given title and checked state.
+func (e *menuEntry) show(title string, checked bool) {
+	if title != e.title {
+		e.item.SetTitle(title)
+		e.title = title
+	}
+	if checked != e.checked {
+		if checked {
+			e.item.Check()
+		} else {
+			e.item.Uncheck()
+		}
+		e.checked = checked
+	}
+	if e.hidden {
+		e.item.Show()
+		e.hidden = false
+	}
+}
+
+// hide hides the entry.
+func (e *menuEntry) hide() {
+	if !e.hidden {
+		e.item.Hide()
+		e.hidden = true
+	}
+}
+
+func setEnabled(item *systray.MenuItem, enabled bool) {
+	if enabled {
+		item.Enable()
+	} else {
+		item.Disable()
+	}
 }
 
 // Run starts the systray. Blocks until quit.
@@ -31,18 +278,102 @@ func (t *Tray) onReady() {
 	systray.SetTitle("🍅")
 	systray.SetTooltip("GoPomodoro")
 
-	mStart := systray.AddMenuItem("Start", "Start Pomodoro")
+	t.mTask = systray.AddMenuItem("", "Current task")
+	t.mTask.Disable()
+	t.mTask.Hide()
+	t.mStart = systray.AddMenuItem("Start", "Start Pomodoro")
+	t.mPause = systray.AddMenuItem("Pause", "Pause Pomodoro")
+	t.mPause.Disable()
+	t.mSkip = systray.AddMenuItem("Skip", "Skip to the next phase")
+	t.mSkip.Disable()
+	t.mInterruption = systray.AddMenuItem("Interruption", "Record an interruption")
+	t.mInternal = t.mInterruption.AddSubMenuItem("Internal (0)", "Record an interruption you caused yourself")
+	t.mExternal = t.mInterruption.AddSubMenuItem("External (0)", "Record an interruption caused by someone else")
+	t.mInterruption.Disable()
+	t.mExtend = systray.AddMenuItem("Extend", "Add time to the current phase")
+	t.mExtend1 = t.mExtend.AddSubMenuItem("+1 minute", "Extend the current phase by 1 minute")
+	t.mExtend5 = t.mExtend.AddSubMenuItem("+5 minutes", "Extend the current phase by 5 minutes")
+	t.mExtend.Disable()
+	t.mStopAfter = systray.AddMenuItemCheckbox("Stop After This Phase", "Return to idle once the current phase ends", false)
+	t.mStopAfter.Disable()
+	t.mDiscardIdle = systray.AddMenuItem("Discard Idle Time", "Do not count the time you were away towards the pomodoro")
+	t.mDiscardIdle.Hide()
 	mStop := systray.AddMenuItem("Stop", "Stop Pomodoro")
+	t.mAbandon = systray.AddMenuItem("Really Abandon?", "Void the running pomodoro")
+	t.mAbandon.Hide()
+	t.mToday = systray.AddMenuItem("Today", "Start a pomodoro on one of today's tasks")
+	for i := range t.todayItems {
+		item := t.mToday.AddSubMenuItemCheckbox("", "Start a pomodoro on this task", false)
+		item.Hide()
+		t.todayItems[i] = menuEntry{item: item, hidden: true}
+	}
+	t.mToday.Disable()
+	if t.Tasks == nil {
+		t.mToday.Hide()
+	}
+	t.mProfile = systray.AddMenuItem("Profile", "Switch to another profile")
+	for _, p := range t.Profiles {
+		item := t.mProfile.AddSubMenuItemCheckbox(p.Name, "Switch to this profile from the next phase on", false)
+		t.profileItems = append(t.profileItems, menuEntry{item: item, title: p.Name})
+	}
+	if len(t.Profiles) == 0 {
+		t.mProfile.Hide()
+	}
 	systray.AddSeparator()
 	mQuit := systray.AddMenuItem("Quit", "Quit GoPomodoro")
 
+	t.ready.Store(true)
+	t.render()
+
+	for i, entry := range t.todayItems {
+		go func() {
+			for range entry.item.ClickedCh {
+				t.startToday(i)
+			}
+		}()
+	}
+
+	for i, entry := range t.profileItems {
+		go func() {
+			for range entry.item.ClickedCh {
+				t.cycle.SetProfile(t.Profiles[i])
+			}
+		}()
+	}
+
 	go func() {
 		for {
 			select {
-			case <-mStart.ClickedCh:
+			case <-t.mStart.ClickedCh:
 				t.cycle.Start()
+			case <-t.mPause.ClickedCh:
+				if t.cycle.IsPaused() {
+					t.cycle.Resume()
+				} else {
+					t.cycle.Pause()
+				}
+			case <-t.mSkip.ClickedCh:
+				t.cycle.Skip()
+			case <-t.mInternal.ClickedCh:
+				t.cycle.Interrupt(gopomodoro.InternalInterruption)
+			case <-t.mExternal.ClickedCh:
+				t.cycle.Interrupt(gopomodoro.ExternalInterruption)
+			case <-t.mExtend1.ClickedCh:
+				_ = t.cycle.Extend(time.Minute)
+			case <-t.mExtend5.ClickedCh:
+				_ = t.cycle.Extend(5 * time.Minute)
+			case <-t.mStopAfter.ClickedCh:
+				if t.cycle.Snapshot().StopPending {
+					t.cycle.CancelStopAfterPhase()
+				} else {
+					t.cycle.StopAfterPhase()
+				}
+			case <-t.mDiscardIdle.ClickedCh:
+				t.cycle.DiscardIdle()
 			case <-mStop.ClickedCh:
 				t.cycle.Stop()
+			case <-t.mAbandon.ClickedCh:
+				t.cycle.Abandon()
 			case <-mQuit.ClickedCh:
 				systray.Quit()
 				return
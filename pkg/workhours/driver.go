@@ -0,0 +1,66 @@
+package workhours
+
+import (
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	"github.com/co0p/gopomodoro/pkg/internal/poll"
+)
+
+// ClosedReason is recorded when a pomodoro is voided because the work
+// window closed.
+const ClosedReason = "work hours ended"
+
+// Driver starts the cycle when a work window opens and stops it when the
+// window closes. Each window starts the cycle at most once, so a cycle
+// stopped by hand stays stopped until the next window, and a window already
+// open when the process starts does not start it at all.
+type Driver struct {
+	Cycle *gopomodoro.Cycle
+	Hours Hours
+
+	// Clock is optional; the system clock is used when nil.
+	Clock gopomodoro.Clock
+
+	// Ticker paces Run.
+	Ticker gopomodoro.Ticker
+
+	// started is the start of the window the cycle was last started in;
+	// open is the window found by the previous Check, if checked.
+	started time.Time
+	open    Interval
+	inside  bool
+	checked bool
+}
+
+// Run checks the hours on every tick of the Ticker. It blocks forever.
+func (d *Driver) Run() {
+	poll.Run(d.Ticker, d.Check)
+}
+
+// Check stops the cycle if the window it was running in has closed and
+// starts it if a window has opened since the last start. The first Check
+// only notes the window it finds, so launching during work hours does not
+// start a pomodoro unasked.
+func (d *Driver) Check() {
+	now := poll.Now(d.Clock)
+	window, inside := d.Hours.At(now)
+	if !d.checked {
+		d.checked = true
+		if inside {
+			d.started = window.Start
+		}
+		d.open, d.inside = window, inside
+		return
+	}
+	if d.inside && (!inside || !window.Start.Equal(d.open.Start)) && !d.Cycle.Is(gopomodoro.Idle) {
+		d.Cycle.Void(ClosedReason)
+	}
+	if inside && !window.Start.Equal(d.started) {
+		d.started = window.Start
+		if d.Cycle.Is(gopomodoro.Idle) {
+			d.Cycle.Start()
+		}
+	}
+	d.open, d.inside = window, inside
+}
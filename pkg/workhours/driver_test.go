@@ -0,0 +1,90 @@
+package workhours_test
+
+import (
+	"testing"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+	pomotest "github.com/co0p/gopomodoro/pkg/testing"
+	"github.com/co0p/gopomodoro/pkg/workhours"
+)
+
+// drivenCycle returns a cycle gated by hours, with its clock set to start,
+// and a driver for it.
+func drivenCycle(start time.Time, hours workhours.Hours) (*gopomodoro.Cycle, *workhours.Driver, *pomotest.MockClock, *pomotest.MockSubscriber) {
+	c, clock, subscriber := pomotest.NewCycle(func(c *gopomodoro.Cycle) { c.Gate = hours })
+	clock.Advance(start.Sub(pomotest.StartTime))
+	return c, &workhours.Driver{Cycle: c, Hours: hours, Clock: clock}, clock, subscriber
+}
+
+func TestDriver_GivenWindowOpens_WhenChecked_ThenStartsOnce(t *testing.T) {
+	c, d, clock, _ := drivenCycle(monday.Add(8*time.Hour+59*time.Minute), officeHours())
+	d.Check()
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatal("expected to stay idle before 09:00")
+	}
+
+	clock.Advance(time.Minute)
+	d.Check()
+	if !c.Is(gopomodoro.Pomodoro) {
+		t.Fatalf("expected a pomodoro at 09:00, got %+v", c.Snapshot())
+	}
+
+	c.Stop()
+	clock.Advance(time.Minute)
+	d.Check()
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatal("expected a cycle stopped by hand to stay stopped")
+	}
+}
+
+func TestDriver_GivenCycleRunningAtClose_WhenChecked_ThenStops(t *testing.T) {
+	c, d, clock, subscriber := drivenCycle(monday.Add(17*time.Hour+30*time.Minute), officeHours())
+	d.Check()
+	c.Start()
+	c.Skip()
+
+	clock.Advance(30 * time.Minute)
+	d.Check()
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected to stop at 18:00, got %+v", c.Snapshot())
+	}
+	if _, ok := subscriber.Last(gopomodoro.Stopped); !ok {
+		t.Fatal("expected a Stopped event")
+	}
+}
+
+func TestDriver_GivenLaunchedDuringWindow_WhenChecked_ThenWaitsForNextWindow(t *testing.T) {
+	c, d, clock, _ := drivenCycle(monday.Add(10*time.Hour), officeHours())
+
+	d.Check()
+	clock.Advance(time.Minute)
+	d.Check()
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected launching at 10:00 not to start a pomodoro, got %+v", c.Snapshot())
+	}
+
+	clock.Advance(23 * time.Hour)
+	d.Check()
+	if !c.Is(gopomodoro.Pomodoro) {
+		t.Fatalf("expected a pomodoro when the next window opens, got %+v", c.Snapshot())
+	}
+}
+
+func TestDriver_GivenWindowOpenedTooLateForAPomodoro_WhenChecked_ThenRefuses(t *testing.T) {
+	var hours workhours.Hours
+	hours.Week[time.Monday] = []workhours.Window{{From: 17*time.Hour + 45*time.Minute, To: 18 * time.Hour}}
+	c, d, clock, subscriber := drivenCycle(monday.Add(17*time.Hour+44*time.Minute), hours)
+
+	d.Check()
+	clock.Advance(time.Minute)
+	d.Check()
+
+	if !c.Is(gopomodoro.Idle) {
+		t.Fatalf("expected to stay idle, got %+v", c.Snapshot())
+	}
+	if _, ok := subscriber.Last(gopomodoro.StartRefused); !ok {
+		t.Fatal("expected a StartRefused event")
+	}
+}
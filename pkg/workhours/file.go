@@ -0,0 +1,133 @@
+package workhours
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// FileName is the name of the work hours file inside the config directory.
+const FileName = "workhours.json"
+
+// file is the on-disk format: windows written as "09:00-12:30", listed per
+// weekday by lower-case name and per exception date as "2006-01-02".
+//
+//	{
+//	  "week": {
+//	    "monday": ["09:00-12:30", "13:30-18:00"],
+//	    "friday": ["09:00-15:00"]
+//	  },
+//	  "exceptions": {
+//	    "2026-12-24": [],
+//	    "2026-12-31": ["10:00-13:00"]
+//	  }
+//	}
+type file struct {
+	Week       map[string][]string `json:"week"`
+	Exceptions map[string][]string `json:"exceptions,omitempty"`
+}
+
+// Parse decodes and validates work hours. Unknown fields, weekdays and
+// malformed windows are rejected.
+func Parse(data []byte) (Hours, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return Hours{}, fmt.Errorf("decode work hours: %w", err)
+	}
+
+	var h Hours
+	for name, spans := range f.Week {
+		day, ok := parseWeekday(name)
+		if !ok {
+			return Hours{}, fmt.Errorf("work hours: unknown weekday %q", name)
+		}
+		windows, err := parseWindows(spans)
+		if err != nil {
+			return Hours{}, fmt.Errorf("work hours: %s: %w", name, err)
+		}
+		h.Week[day] = windows
+	}
+	for date, spans := range f.Exceptions {
+		t, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return Hours{}, fmt.Errorf("work hours: exception %q: %w", date, err)
+		}
+		windows, err := parseWindows(spans)
+		if err != nil {
+			return Hours{}, fmt.Errorf("work hours: %s: %w", date, err)
+		}
+		if h.Exceptions == nil {
+			h.Exceptions = map[Date][]Window{}
+		}
+		h.Exceptions[DateOf(t)] = windows
+	}
+	return h, nil
+}
+
+// Load reads the work hours file at path. If it does not exist, the
+// returned error wraps os.ErrNotExist.
+func Load(path string) (Hours, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Hours{}, fmt.Errorf("read work hours: %w", err)
+	}
+	h, err := Parse(data)
+	if err != nil {
+		return Hours{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return h, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// parseWindows parses spans such as "09:00-12:30", which must be in order
+// and must not overlap.
+func parseWindows(spans []string) ([]Window, error) {
+	windows := make([]Window, 0, len(spans))
+	for _, span := range spans {
+		from, to, ok := strings.Cut(span, "-")
+		if !ok {
+			return nil, fmt.Errorf("window %q: expected HH:MM-HH:MM", span)
+		}
+		var w Window
+		var err error
+		if w.From, err = parseClock(from); err != nil {
+			return nil, fmt.Errorf("window %q: %w", span, err)
+		}
+		if w.To, err = parseClock(to); err != nil {
+			return nil, fmt.Errorf("window %q: %w", span, err)
+		}
+		if w.To <= w.From {
+			return nil, fmt.Errorf("window %q: must end after it starts", span)
+		}
+		if n := len(windows); n > 0 && w.From < windows[n-1].To {
+			return nil, fmt.Errorf("window %q: overlaps or precedes the one before", span)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// parseClock parses a time of day from "00:00" to "24:00".
+func parseClock(s string) (time.Duration, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(strings.TrimSpace(s), "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	if h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
@@ -0,0 +1,62 @@
+package workhours_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/workhours"
+)
+
+func TestParse_GivenValidFile_WhenParsed_ThenWeekAndExceptionsAreSet(t *testing.T) {
+	data := []byte(`{
+		"week": {"Monday": ["09:00-12:30", "13:30-18:00"], "friday": ["09:00-15:00"]},
+		"exceptions": {"2026-12-24": [], "2026-12-31": ["10:00-13:00"]}
+	}`)
+
+	h, err := workhours.Parse(data)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(h.Week[time.Monday]) != 2 || h.Week[time.Monday][1] != (workhours.Window{From: 13*time.Hour + 30*time.Minute, To: 18 * time.Hour}) {
+		t.Fatalf("expected two Monday windows, got %+v", h.Week[time.Monday])
+	}
+	christmasEve, ok := h.Exceptions[workhours.Date{Year: 2026, Month: time.December, Day: 24}]
+	if !ok || len(christmasEve) != 0 || len(h.Exceptions) != 2 {
+		t.Fatalf("expected Christmas Eve off, got %+v", h.Exceptions)
+	}
+}
+
+func TestParse_GivenInvalidFiles_WhenParsed_ThenFails(t *testing.T) {
+	for _, tc := range []struct {
+		name, data, problem string
+	}{
+		{"unknown field", `{"weeks": {}}`, "unknown field"},
+		{"unknown weekday", `{"week": {"mondai": ["09:00-18:00"]}}`, "unknown weekday"},
+		{"no dash", `{"week": {"monday": ["09:00"]}}`, "expected HH:MM-HH:MM"},
+		{"bad time", `{"week": {"monday": ["09:00-25:00"]}}`, "invalid time"},
+		{"backwards", `{"week": {"monday": ["18:00-09:00"]}}`, "must end after it starts"},
+		{"overlap", `{"week": {"monday": ["09:00-13:00", "12:00-18:00"]}}`, "overlaps"},
+		{"bad date", `{"week": {}, "exceptions": {"24.12.2026": []}}`, "exception"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := workhours.Parse([]byte(tc.data))
+
+			if err == nil || !strings.Contains(err.Error(), tc.problem) {
+				t.Fatalf("expected error about %q, got %v", tc.problem, err)
+			}
+		})
+	}
+}
+
+func TestLoad_GivenMissingFile_WhenLoaded_ThenNotExist(t *testing.T) {
+	_, err := workhours.Load(filepath.Join(t.TempDir(), workhours.FileName))
+
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected os.ErrNotExist, got %v", err)
+	}
+}
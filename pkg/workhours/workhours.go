@@ -0,0 +1,114 @@
+// Package workhours describes the hours of the week in which pomodoros may
+// run, and drives a cycle by them: starting it when a work window opens,
+// stopping it when the window closes and refusing pomodoros that would not
+// finish in time.
+package workhours
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	gopomodoro "github.com/co0p/gopomodoro/pkg"
+)
+
+var (
+	// ErrOutsideHours is returned when a pomodoro would start outside any
+	// work window.
+	ErrOutsideHours = errors.New("outside work hours")
+	// ErrClosing is returned when a pomodoro would not finish before the
+	// work window closes.
+	ErrClosing = errors.New("not enough time left before work hours end")
+)
+
+// Window is a span of a day, given as offsets from midnight. It must not
+// cross midnight.
+type Window struct {
+	From time.Duration
+	To   time.Duration
+}
+
+// Date is a calendar day, independent of time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Interval is a work window on a particular day.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t lies within [Start, End).
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Hours is a weekly schedule of work windows. Exceptions replace the
+// windows of the weekday on particular dates; a date without windows is a
+// day off.
+type Hours struct {
+	Week       [7][]Window
+	Exceptions map[Date][]Window
+}
+
+// On returns the work windows on the day of t, in t's location.
+func (h Hours) On(t time.Time) []Interval {
+	windows, ok := h.Exceptions[DateOf(t)]
+	if !ok {
+		windows = h.Week[t.Weekday()]
+	}
+	y, m, d := t.Date()
+	intervals := make([]Interval, 0, len(windows))
+	for _, w := range windows {
+		intervals = append(intervals, Interval{
+			Start: clockTime(y, m, d, w.From, t.Location()),
+			End:   clockTime(y, m, d, w.To, t.Location()),
+		})
+	}
+	return intervals
+}
+
+// At returns the work window containing t, if any.
+func (h Hours) At(t time.Time) (Interval, bool) {
+	for _, i := range h.On(t) {
+		if i.Contains(t) {
+			return i, true
+		}
+	}
+	return Interval{}, false
+}
+
+// AdmitPomodoro admits a pomodoro of length d starting at t only if it
+// finishes within the work window t falls into.
+func (h Hours) AdmitPomodoro(t time.Time, d time.Duration) (gopomodoro.Admission, error) {
+	i, ok := h.At(t)
+	if !ok {
+		return gopomodoro.Admission{}, ErrOutsideHours
+	}
+	if t.Add(d).After(i.End) {
+		return gopomodoro.Admission{}, fmt.Errorf("%w at %s", ErrClosing, i.End.Format("15:04"))
+	}
+	return gopomodoro.Admit(d), nil
+}
+
+// clockTime returns the wall-clock time offset after midnight on the given
+// day. It counts hours and minutes on the clock rather than elapsed time, so
+// windows keep their times on days with a daylight saving change.
+func clockTime(y int, m time.Month, d int, offset time.Duration, loc *time.Location) time.Time {
+	h := int(offset / time.Hour)
+	min := int(offset % time.Hour / time.Minute)
+	return time.Date(y, m, d, h, min, 0, 0, loc)
+}
@@ -0,0 +1,72 @@
+package workhours_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/co0p/gopomodoro/pkg/workhours"
+)
+
+// monday is a Monday.
+var monday = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+func officeHours() workhours.Hours {
+	var h workhours.Hours
+	for day := time.Monday; day <= time.Friday; day++ {
+		h.Week[day] = []workhours.Window{{From: 9 * time.Hour, To: 18 * time.Hour}}
+	}
+	return h
+}
+
+func TestHours_GivenWeekday_WhenAskedAt_ThenFindsWindow(t *testing.T) {
+	h := officeHours()
+
+	window, ok := h.At(monday.Add(10 * time.Hour))
+
+	if !ok || !window.Start.Equal(monday.Add(9*time.Hour)) || !window.End.Equal(monday.Add(18*time.Hour)) {
+		t.Fatalf("expected 09:00-18:00, got %+v, %v", window, ok)
+	}
+	if _, ok := h.At(monday.Add(18 * time.Hour)); ok {
+		t.Fatal("expected the window to be closed at 18:00")
+	}
+	if _, ok := h.At(monday.AddDate(0, 0, 5).Add(10 * time.Hour)); ok {
+		t.Fatal("expected Saturday to be off")
+	}
+}
+
+func TestHours_GivenExceptionDate_WhenAskedAt_ThenReplacesWeekday(t *testing.T) {
+	h := officeHours()
+	h.Exceptions = map[workhours.Date][]workhours.Window{
+		workhours.DateOf(monday):                  nil,
+		workhours.DateOf(monday.AddDate(0, 0, 1)): {{From: 10 * time.Hour, To: 12 * time.Hour}},
+	}
+
+	_, mondayOpen := h.At(monday.Add(10 * time.Hour))
+	tuesday, tuesdayOpen := h.At(monday.AddDate(0, 0, 1).Add(11 * time.Hour))
+
+	if mondayOpen {
+		t.Fatal("expected Monday to be a day off")
+	}
+	if !tuesdayOpen || tuesday.End.Hour() != 12 {
+		t.Fatalf("expected Tuesday to close at 12:00, got %+v", tuesday)
+	}
+}
+
+func TestHours_GivenPomodoro_WhenAdmitted_ThenMustFinishBeforeClose(t *testing.T) {
+	h := officeHours()
+
+	_, fits := h.AdmitPomodoro(monday.Add(17*time.Hour+35*time.Minute), 25*time.Minute)
+	_, late := h.AdmitPomodoro(monday.Add(17*time.Hour+36*time.Minute), 25*time.Minute)
+	_, outside := h.AdmitPomodoro(monday.Add(8*time.Hour), 25*time.Minute)
+
+	if fits != nil {
+		t.Fatalf("expected a pomodoro ending at 18:00 to fit, got %v", fits)
+	}
+	if !errors.Is(late, workhours.ErrClosing) {
+		t.Fatalf("expected ErrClosing, got %v", late)
+	}
+	if !errors.Is(outside, workhours.ErrOutsideHours) {
+		t.Fatalf("expected ErrOutsideHours, got %v", outside)
+	}
+}
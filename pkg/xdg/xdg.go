@@ -0,0 +1,41 @@
+// Package xdg resolves per-user directories following the XDG Base
+// Directory Specification.
+package xdg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// App is the subdirectory used for gopomodoro's files.
+const App = "gopomodoro"
+
+// StateDir returns the directory for state that should survive restarts,
+// $XDG_STATE_HOME/gopomodoro, falling back to ~/.local/state/gopomodoro.
+func StateDir() (string, error) {
+	return dir("XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// DataDir returns the directory for user data such as the session history,
+// $XDG_DATA_HOME/gopomodoro, falling back to ~/.local/share/gopomodoro.
+func DataDir() (string, error) {
+	return dir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// ConfigDir returns the directory for user configuration such as profiles,
+// $XDG_CONFIG_HOME/gopomodoro, falling back to ~/.config/gopomodoro.
+func ConfigDir() (string, error) {
+	return dir("XDG_CONFIG_HOME", ".config")
+}
+
+func dir(env, fallback string) (string, error) {
+	if base := os.Getenv(env); filepath.IsAbs(base) {
+		return filepath.Join(base, App), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", env, err)
+	}
+	return filepath.Join(home, fallback, App), nil
+}
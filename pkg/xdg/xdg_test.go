@@ -0,0 +1,63 @@
+package xdg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/co0p/gopomodoro/pkg/xdg"
+)
+
+func TestStateDir_GivenXDGStateHome_WhenResolved_ThenUsesIt(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+
+	dir, err := xdg.StateDir()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dir != filepath.Join("/tmp/state", "gopomodoro") {
+		t.Fatalf("expected /tmp/state/gopomodoro, got %s", dir)
+	}
+}
+
+func TestStateDir_GivenRelativeXDGStateHome_WhenResolved_ThenFallsBackToHome(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "relative")
+	t.Setenv("HOME", "/home/pomo")
+
+	dir, err := xdg.StateDir()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dir != filepath.Join("/home/pomo", ".local", "state", "gopomodoro") {
+		t.Fatalf("expected ~/.local/state/gopomodoro, got %s", dir)
+	}
+}
+
+func TestDataDir_GivenNoXDGDataHome_WhenResolved_ThenUsesLocalShare(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/pomo")
+
+	dir, err := xdg.DataDir()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dir != filepath.Join("/home/pomo", ".local", "share", "gopomodoro") {
+		t.Fatalf("expected ~/.local/share/gopomodoro, got %s", dir)
+	}
+}
+
+func TestConfigDir_GivenNoXDGConfigHome_WhenResolved_ThenUsesDotConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/pomo")
+
+	dir, err := xdg.ConfigDir()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dir != filepath.Join("/home/pomo", ".config", "gopomodoro") {
+		t.Fatalf("expected ~/.config/gopomodoro, got %s", dir)
+	}
+}